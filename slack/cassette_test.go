@@ -0,0 +1,414 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/require"
+)
+
+// The modes of the cassette server, selected with SLACK_CASSETTE.
+const (
+	cassetteRecord = "record"
+	cassetteReplay = "replay"
+
+	cassetteDir = "testdata/cassettes"
+)
+
+var (
+	// testCassettes is the cassette server the provider talks to when
+	// SLACK_CASSETTE is set, nil otherwise.
+	testCassettes *cassetteServer
+
+	cassetteFileChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+	// cassetteHeaders are the response headers kept in the cassettes.
+	cassetteHeaders = []string{"Content-Type", "X-Oauth-Scopes"}
+)
+
+// cassette holds the Slack API interactions of a test, in the order they
+// were recorded, and the random names the test generated.
+type cassette struct {
+	Names        []string               `json:"names,omitempty"`
+	Interactions []*cassetteInteraction `json:"interactions"`
+
+	nextName int
+}
+
+type cassetteInteraction struct {
+	Method  string            `json:"method"`
+	Form    url.Values        `json:"form,omitempty"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body"`
+
+	used bool
+}
+
+// cassetteServer sits between the provider and Slack. In record mode it
+// forwards every call to upstream and records it into the cassette of the
+// active test; in replay mode it answers from that cassette. Tokens and
+// email addresses are scrubbed from the cassettes.
+//
+// Calls are attributed to the test that last activated its cassette, so the
+// tests must not run in parallel while using it.
+type cassetteServer struct {
+	*httptest.Server
+
+	mode     string
+	upstream string
+	dir      string
+	client   *http.Client
+
+	mu        sync.Mutex
+	cassettes map[string]*cassette
+	active    *cassette
+}
+
+func newCassetteServer(mode, upstream, dir string) *cassetteServer {
+	s := &cassetteServer{
+		mode:      mode,
+		upstream:  upstream,
+		dir:       dir,
+		client:    &http.Client{},
+		cassettes: map[string]*cassette{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// apiURL returns the base URL to configure the provider with.
+func (s *cassetteServer) apiURL() string {
+	return s.URL + "/api/"
+}
+
+func (s *cassetteServer) path(name string) string {
+	return filepath.Join(s.dir, cassetteFileChars.ReplaceAllString(name, "_")+".json")
+}
+
+// activate makes the cassette of the given test the one calls are recorded
+// into or replayed from. In replay mode it returns an error wrapping
+// os.ErrNotExist if the test has no cassette.
+func (s *cassetteServer) activate(name string) (*cassette, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.cassettes[name]; ok {
+		s.active = c
+		return c, nil
+	}
+
+	c := &cassette{}
+	if s.mode == cassetteReplay {
+		data, err := os.ReadFile(s.path(name))
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, c); err != nil {
+			return nil, fmt.Errorf("couldn't decode the cassette of %s: %w", name, err)
+		}
+	}
+	s.cassettes[name] = c
+	s.active = c
+	return c, nil
+}
+
+// save writes the cassette recorded for the given test.
+func (s *cassetteServer) save(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.cassettes[name]
+	if !ok {
+		return fmt.Errorf("no cassette recorded for %s", name)
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path(name), append(data, '\n'), 0o644)
+}
+
+// randomName returns a random name with the given prefix. The names are
+// recorded in the cassette so that replays send the same requests.
+func (s *cassetteServer) randomName(c *cassette, prefix string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.mode == cassetteRecord {
+		name := acctest.RandomWithPrefix(prefix)
+		c.Names = append(c.Names, name)
+		return name, nil
+	}
+	if c.nextName >= len(c.Names) {
+		return "", fmt.Errorf("the cassette has no more recorded names for the %s prefix", prefix)
+	}
+	name := c.Names[c.nextName]
+	c.nextName++
+	if !strings.HasPrefix(name, prefix) {
+		return "", fmt.Errorf("the cassette recorded %s where a name with the %s prefix was expected", name, prefix)
+	}
+	return name, nil
+}
+
+func (s *cassetteServer) serve(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for k, v := range r.URL.Query() {
+		form[k] = append(form[k], v...)
+	}
+	method := path.Base(r.URL.Path)
+	form = scrubCassetteForm(form)
+
+	s.mu.Lock()
+	c := s.active
+	s.mu.Unlock()
+	if c == nil {
+		http.Error(w, "no test has activated a cassette", http.StatusNotFound)
+		return
+	}
+
+	if s.mode == cassetteReplay {
+		s.replay(w, c, method, form)
+		return
+	}
+	s.record(w, r, c, method, form, body)
+}
+
+func (s *cassetteServer) replay(w http.ResponseWriter, c *cassette, method string, form url.Values) {
+	s.mu.Lock()
+	var match *cassetteInteraction
+	for _, interaction := range c.Interactions {
+		if interaction.Method != method || interaction.Form.Encode() != form.Encode() {
+			continue
+		}
+		// Identical calls are answered in the order they were recorded,
+		// repeating the last answer if the test makes more of them.
+		match = interaction
+		if !interaction.used {
+			break
+		}
+	}
+	if match != nil {
+		match.used = true
+	}
+	s.mu.Unlock()
+
+	if match == nil {
+		log.Printf("[ERROR] no recorded interaction for %s with %s", method, form.Encode())
+		http.Error(w, fmt.Sprintf("no recorded interaction for %s", method), http.StatusNotFound)
+		return
+	}
+
+	for k, v := range match.Headers {
+		w.Header().Set(k, v)
+	}
+	w.WriteHeader(match.Status)
+	_, _ = w.Write(match.Body)
+}
+
+func (s *cassetteServer) record(w http.ResponseWriter, r *http.Request, c *cassette, method string, form url.Values, body []byte) {
+	target := s.upstream + method
+	if r.URL.RawQuery != "" {
+		target += "?" + r.URL.RawQuery
+	}
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, target, bytes.NewReader(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	for _, h := range []string{"Authorization", "Content-Type"} {
+		if v := r.Header.Get(h); v != "" {
+			req.Header.Set(h, v)
+		}
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	// Rate limits and server errors are retried by the provider, so they
+	// are passed through without being recorded.
+	if resp.StatusCode == http.StatusOK {
+		interaction := &cassetteInteraction{
+			Method:  method,
+			Form:    form,
+			Status:  resp.StatusCode,
+			Headers: map[string]string{},
+			Body:    scrubCassetteBody(respBody),
+		}
+		for _, h := range cassetteHeaders {
+			if v := resp.Header.Get(h); v != "" {
+				interaction.Headers[h] = v
+			}
+		}
+		s.mu.Lock()
+		c.Interactions = append(c.Interactions, interaction)
+		s.mu.Unlock()
+	}
+
+	for k, v := range resp.Header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write(respBody)
+}
+
+// scrubCassetteForm drops the token and scrubs the email addresses of a
+// request.
+func scrubCassetteForm(form url.Values) url.Values {
+	scrubbed := url.Values{}
+	for k, values := range form {
+		if k == "token" {
+			continue
+		}
+		for _, v := range values {
+			scrubbed.Add(k, scrubCassetteText(v))
+		}
+	}
+	return scrubbed
+}
+
+func scrubCassetteBody(body []byte) json.RawMessage {
+	scrubbed := scrubCassetteText(string(body))
+	if json.Valid([]byte(scrubbed)) {
+		return json.RawMessage(scrubbed)
+	}
+	data, _ := json.Marshal(scrubbed)
+	return data
+}
+
+// scrubCassetteText replaces tokens, and email addresses with a placeholder
+// derived from the address, so that the same address is always scrubbed the
+// same way in requests and responses.
+func scrubCassetteText(s string) string {
+	s = slackTokenPattern.ReplaceAllString(s, "xoxb-scrubbed")
+	return emailPattern.ReplaceAllStringFunc(s, func(email string) string {
+		if strings.HasSuffix(email, "@example.com") {
+			return email
+		}
+		sum := sha256.Sum256([]byte(strings.ToLower(email)))
+		return fmt.Sprintf("user-%x@example.com", sum[:4])
+	})
+}
+
+// testAccCassette activates the cassette of the test when SLACK_CASSETTE is
+// set. In record mode the cassette is written once the test passes; in replay
+// mode tests without a cassette are skipped.
+func testAccCassette(t *testing.T) *cassette {
+	if testCassettes == nil {
+		return nil
+	}
+
+	c, err := testCassettes.activate(t.Name())
+	if errors.Is(err, os.ErrNotExist) {
+		t.Skipf("no cassette recorded for %s", t.Name())
+	}
+	require.NoError(t, err)
+
+	if testCassettes.mode == cassetteRecord && len(c.Interactions) == 0 && len(c.Names) == 0 {
+		t.Cleanup(func() {
+			if t.Failed() {
+				t.Logf("not saving the cassette of %s as the test failed", t.Name())
+				return
+			}
+			require.NoError(t, testCassettes.save(t.Name()))
+		})
+	}
+	return c
+}
+
+// testAccRandomName returns a random name with the given prefix, or the one
+// recorded in the cassette of the test when replaying.
+func testAccRandomName(t *testing.T, prefix string) string {
+	c := testAccCassette(t)
+	if c == nil {
+		return acctest.RandomWithPrefix(prefix)
+	}
+	name, err := testCassettes.randomName(c, prefix)
+	require.NoError(t, err)
+	return name
+}
+
+func TestCassetteServer(t *testing.T) {
+	fake := testFakeSlack(t)
+	dir := t.TempDir()
+
+	run := func(server *cassetteServer, token string) (*slack.User, *slack.Channel, string) {
+		c, err := server.activate(t.Name())
+		require.NoError(t, err)
+		name, err := server.randomName(c, conversationNamePrefix)
+		require.NoError(t, err)
+
+		client := slack.New(token, slack.OptionAPIURL(server.apiURL()))
+		user, err := client.GetUserByEmailContext(context.Background(), testUser00.email)
+		require.NoError(t, err)
+		channel, err := client.CreateConversationContext(context.Background(), slack.CreateConversationParams{ChannelName: name})
+		require.NoError(t, err)
+		return user, channel, name
+	}
+
+	recorder := newCassetteServer(cassetteRecord, fake.apiURL(), dir)
+	t.Cleanup(recorder.Close)
+	recordedUser, recordedChannel, recordedName := run(recorder, fakeSlackToken)
+	require.Equal(t, testUser00.email, recordedUser.Profile.Email, "the recording run sees the real responses")
+	require.NoError(t, recorder.save(t.Name()))
+
+	data, err := os.ReadFile(recorder.path(t.Name()))
+	require.NoError(t, err)
+	require.NotContains(t, string(data), fakeSlackToken)
+	require.NotContains(t, string(data), testUser00.email)
+
+	replayer := newCassetteServer(cassetteReplay, "", dir)
+	t.Cleanup(replayer.Close)
+	user, channel, name := run(replayer, "xoxb-no-token")
+	require.Equal(t, recordedName, name)
+	require.Equal(t, recordedUser.ID, user.ID)
+	require.Equal(t, scrubCassetteText(testUser00.email), user.Profile.Email)
+	require.Equal(t, recordedChannel.ID, channel.ID)
+	require.Equal(t, 1, fake.callCount("conversations.create"), "replays don't reach Slack")
+
+	client := slack.New("xoxb-no-token", slack.OptionAPIURL(replayer.apiURL()))
+	_, err = client.GetConversationInfoContext(context.Background(), &slack.GetConversationInfoInput{ChannelID: "C0UNKNOWN"})
+	require.Error(t, err, "calls that were not recorded fail")
+
+	_, err = replayer.activate("TestNotRecorded")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,259 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/slack-go/slack"
+)
+
+// equivalentScopes lists, for a scope, the other scopes that grant the same
+// permissions, e.g. user tokens get channels:write instead of channels:manage.
+var equivalentScopes = map[string][]string{
+	"channels:manage": {"channels:write"},
+}
+
+// The provider arguments holding the Slack tokens. token is used for any
+// call that doesn't have a more specific token configured.
+const (
+	tokenDefault = "token"
+	tokenBot     = "bot_token"
+	tokenUser    = "user_token"
+	tokenAdmin   = "admin_token"
+)
+
+var tokenArguments = []string{tokenDefault, tokenBot, tokenUser, tokenAdmin}
+
+// providerMeta is the meta value shared by all the resources and data sources.
+type providerMeta struct {
+	// clients holds a client for each configured token, keyed by the
+	// provider argument of the token.
+	clients map[string]*apiClient
+
+	// teamID is the workspace resources are managed in when they don't set
+	// their own team_id.
+	teamID string
+
+	// conversationNaming is the naming policy of the conversations.
+	conversationNaming *conversationNaming
+}
+
+// resourceTeamID returns the team_id of a resource or data source, or the
+// one of the provider when it is not set.
+func (m *providerMeta) resourceTeamID(d *schema.ResourceData) string {
+	if teamID, ok := d.GetOk("team_id"); ok {
+		return teamID.(string)
+	}
+	return m.teamID
+}
+
+// botClient returns the client to manage channels and their members.
+func (m *providerMeta) botClient() (*apiClient, error) {
+	return m.tokenClient(tokenBot)
+}
+
+// userClient returns the client for the APIs that need a user token, such as
+// usergroups.
+func (m *providerMeta) userClient() (*apiClient, error) {
+	return m.tokenClient(tokenUser)
+}
+
+// adminClient returns the client for the admin.* APIs, which need a user
+// token of an org admin.
+func (m *providerMeta) adminClient() (*apiClient, error) {
+	return m.tokenClient(tokenAdmin)
+}
+
+// hasAdminAPIs returns whether the admin APIs that manage conversations can
+// be used, i.e. whether the token of adminClient has the
+// admin.conversations:write scope. Tokens whose scopes are unknown are assumed
+// to have it.
+func (m *providerMeta) hasAdminAPIs() bool {
+	client, err := m.adminClient()
+	return err == nil && client.requireScopes("", "admin.conversations:write") == nil
+}
+
+func (m *providerMeta) tokenClient(name string) (*apiClient, error) {
+	if client, ok := m.clients[name]; ok {
+		return client, nil
+	}
+	if client, ok := m.clients[tokenDefault]; ok {
+		return client, nil
+	}
+	return nil, fmt.Errorf("neither %s nor %s is configured in the provider, one of them is needed for this operation", name, tokenDefault)
+}
+
+// apiClient is a Slack client together with the identity and the scopes of
+// its token, as reported by auth.test.
+type apiClient struct {
+	*slack.Client
+
+	// name is the provider argument the token was configured with.
+	name       string
+	token      string
+	endpoint   string
+	httpClient *http.Client
+
+	mu   sync.Mutex
+	auth *authInfo
+}
+
+// authInfo is the identity of a token. scopes is nil when Slack doesn't
+// report the granted scopes, e.g. for legacy tokens.
+type authInfo struct {
+	userID       string
+	botID        string
+	teamID       string
+	enterpriseID string
+	scopes       []string
+}
+
+func newAPIClient(c *config, token string) (*apiClient, error) {
+	httpClient, err := c.httpClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiClient{
+		Client:     slack.New(token, slack.OptionHTTPClient(httpClient), slack.OptionAPIURL(c.endpoint())),
+		token:      token,
+		endpoint:   c.endpoint(),
+		httpClient: httpClient,
+	}, nil
+}
+
+// call invokes a Slack Web API method and decodes its response into out,
+// which may be nil. It returns the response headers.
+func (c *apiClient) call(ctx context.Context, method string, values url.Values, out interface{}) (http.Header, error) {
+	if values == nil {
+		values = url.Values{}
+	}
+	values.Set("token", c.token)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+method, strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.Header, fmt.Errorf("%s returned %s", method, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.Header, fmt.Errorf("couldn't read %s response: %w", method, err)
+	}
+
+	var slackResponse slack.SlackResponse
+	if err := json.Unmarshal(body, &slackResponse); err != nil {
+		return resp.Header, fmt.Errorf("couldn't decode %s response: %w", method, err)
+	}
+	if !slackResponse.Ok {
+		return resp.Header, slack.SlackErrorResponse{Err: slackResponse.Error, ResponseMetadata: slackResponse.ResponseMetadata}
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(body, out); err != nil {
+			return resp.Header, fmt.Errorf("couldn't decode %s response: %w", method, err)
+		}
+	}
+	return resp.Header, nil
+}
+
+// authenticate calls auth.test and records the identity of the token.
+func (c *apiClient) authenticate(ctx context.Context) (*authInfo, error) {
+	var resp slack.AuthTestResponse
+	header, err := c.call(ctx, "auth.test", nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	auth := &authInfo{
+		userID:       resp.UserID,
+		botID:        resp.BotID,
+		teamID:       resp.TeamID,
+		enterpriseID: resp.EnterpriseID,
+	}
+	if v, ok := header["X-Oauth-Scopes"]; ok {
+		auth.scopes = []string{}
+		for _, scope := range strings.Split(strings.Join(v, ","), ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				auth.scopes = append(auth.scopes, scope)
+			}
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.auth = auth
+	return auth, nil
+}
+
+// identity returns the identity of the token. auth.test is only called if
+// the credentials were not validated when the provider was configured.
+func (c *apiClient) identity(ctx context.Context) (*authInfo, error) {
+	c.mu.Lock()
+	auth := c.auth
+	c.mu.Unlock()
+	if auth != nil {
+		return auth, nil
+	}
+
+	auth, err := c.authenticate(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error authenticating with slack: %w", err)
+	}
+	return auth, nil
+}
+
+// requireScopes returns an error naming the first of the given scopes that
+// was not granted to the token. It never fails when the scopes are unknown,
+// e.g. because credentials validation was skipped.
+func (c *apiClient) requireScopes(resource string, scopes ...string) error {
+	c.mu.Lock()
+	auth := c.auth
+	c.mu.Unlock()
+	if auth == nil || auth.scopes == nil {
+		return nil
+	}
+
+	for _, scope := range scopes {
+		if !auth.hasScope(scope) {
+			return fmt.Errorf("the Slack %s is missing the %s scope required by %s", c.tokenName(), scope, resource)
+		}
+	}
+	return nil
+}
+
+func (c *apiClient) tokenName() string {
+	if c.name == "" {
+		return tokenDefault
+	}
+	return c.name
+}
+
+func (a *authInfo) hasScope(scope string) bool {
+	if contains(a.scopes, scope) {
+		return true
+	}
+	for _, equivalent := range equivalentScopes[scope] {
+		if contains(a.scopes, equivalent) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func testScopesServer(t *testing.T, token, scopes string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("token") != token {
+			writeSlackResponse(w, `{"ok": false, "error": "invalid_auth"}`)
+			return
+		}
+		w.Header().Set("X-OAuth-Scopes", scopes)
+		writeSlackResponse(w, `{"ok": true, "user_id": "U0123456789", "bot_id": "B0123456789", "team_id": "T0123456789"}`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAPIClientAuthenticate(t *testing.T) {
+	server := testScopesServer(t, "xoxb-test", "channels:read,groups:read, groups:write")
+
+	client, err := newAPIClient(&config{apiURL: server.URL}, "xoxb-test")
+	require.NoError(t, err)
+	require.NoError(t, client.requireScopes("slack_conversation", "usergroups:write"), "scopes are unknown before authenticating")
+
+	auth, err := client.authenticate(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "U0123456789", auth.userID)
+	require.Equal(t, "B0123456789", auth.botID)
+	require.Equal(t, "T0123456789", auth.teamID)
+	require.Equal(t, []string{"channels:read", "groups:read", "groups:write"}, auth.scopes)
+
+	require.NoError(t, client.requireScopes("slack_conversation", "groups:read", "groups:write"))
+	require.EqualError(t, client.requireScopes("slack_usergroup", "usergroups:read"),
+		"the Slack token is missing the usergroups:read scope required by slack_usergroup")
+
+	identity, err := client.identity(context.Background())
+	require.NoError(t, err)
+	require.Same(t, auth, identity)
+}
+
+func TestAuthInfoHasScope(t *testing.T) {
+	auth := &authInfo{scopes: []string{"channels:write"}}
+
+	require.True(t, auth.hasScope("channels:write"))
+	require.True(t, auth.hasScope("channels:manage"), "channels:write grants the same permissions as channels:manage")
+	require.False(t, auth.hasScope("groups:write"))
+}
+
+func TestProviderConfigureValidatesCredentials(t *testing.T) {
+	server := testScopesServer(t, "xoxb-test", "channels:read")
+
+	configure := func(raw map[string]interface{}) (interface{}, diag.Diagnostics) {
+		raw["api_url"] = server.URL
+		return providerConfigure(context.Background(), schema.TestResourceDataRaw(t, Provider().Schema, raw))
+	}
+
+	t.Run("valid token", func(t *testing.T) {
+		meta, diags := configure(map[string]interface{}{"token": "xoxb-test"})
+		require.False(t, diags.HasError(), "%v", diags)
+		require.Equal(t, []string{"channels:read"}, meta.(*providerMeta).clients[tokenDefault].auth.scopes)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		_, diags := configure(map[string]interface{}{"token": "xoxb-invalid"})
+		require.True(t, diags.HasError())
+		require.Contains(t, diags[0].Summary, "invalid_auth")
+	})
+
+	t.Run("skip credentials validation", func(t *testing.T) {
+		meta, diags := configure(map[string]interface{}{"token": "xoxb-invalid", "skip_credentials_validation": true})
+		require.False(t, diags.HasError(), "%v", diags)
+		require.Nil(t, meta.(*providerMeta).clients[tokenDefault].auth)
+	})
+}
+
+func TestProviderMetaTokenClient(t *testing.T) {
+	bot, user := &apiClient{name: tokenBot}, &apiClient{name: tokenDefault}
+
+	t.Run("uses the specific token when configured", func(t *testing.T) {
+		meta := &providerMeta{clients: map[string]*apiClient{tokenBot: bot, tokenDefault: user}}
+
+		client, err := meta.botClient()
+		require.NoError(t, err)
+		require.Same(t, bot, client)
+	})
+
+	t.Run("falls back to token", func(t *testing.T) {
+		meta := &providerMeta{clients: map[string]*apiClient{tokenBot: bot, tokenDefault: user}}
+
+		client, err := meta.userClient()
+		require.NoError(t, err)
+		require.Same(t, user, client)
+	})
+
+	t.Run("names the missing token", func(t *testing.T) {
+		meta := &providerMeta{clients: map[string]*apiClient{tokenBot: bot}}
+
+		_, err := meta.adminClient()
+		require.EqualError(t, err, "neither admin_token nor token is configured in the provider, one of them is needed for this operation")
+	})
+}
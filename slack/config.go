@@ -0,0 +1,113 @@
+package slack
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/slack-go/slack"
+)
+
+// config holds the provider settings needed to build a Slack client.
+type config struct {
+	// tokens holds the configured tokens, keyed by provider argument.
+	tokens         map[string]string
+	apiURL         string
+	requestTimeout time.Duration
+	httpProxy      string
+	caBundleFile   string
+	maxRetries     int
+	maxBackoff     time.Duration
+	redactEmails   bool
+}
+
+func newConfig(d *schema.ResourceData) (*config, error) {
+	c := &config{
+		tokens:       map[string]string{},
+		apiURL:       d.Get("api_url").(string),
+		httpProxy:    d.Get("http_proxy").(string),
+		caBundleFile: d.Get("ca_bundle_file").(string),
+		maxRetries:   d.Get("max_retries").(int),
+		redactEmails: d.Get("log_redact_emails").(bool),
+	}
+
+	for _, name := range tokenArguments {
+		if token, ok := d.GetOk(name); ok {
+			c.tokens[name] = token.(string)
+		}
+	}
+
+	var err error
+	if c.maxBackoff, err = time.ParseDuration(d.Get("max_backoff").(string)); err != nil {
+		return nil, fmt.Errorf("invalid max_backoff: %s", err)
+	}
+
+	if v, ok := d.GetOk("request_timeout"); ok {
+		if c.requestTimeout, err = time.ParseDuration(v.(string)); err != nil {
+			return nil, fmt.Errorf("invalid request_timeout: %s", err)
+		}
+	}
+
+	return c, nil
+}
+
+// endpoint returns the Slack Web API base URL, which slack-go expects to end
+// with a slash.
+func (c *config) endpoint() string {
+	if c.apiURL == "" {
+		return slack.APIURL
+	}
+	return strings.TrimSuffix(c.apiURL, "/") + "/"
+}
+
+func (c *config) httpClient() (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if c.httpProxy != "" {
+		proxyURL, err := url.Parse(c.httpProxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid http_proxy %s: %s", c.httpProxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if c.caBundleFile != "" {
+		pool, err := c.certPool()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			RootCAs:    pool,
+		}
+	}
+
+	return &http.Client{
+		Transport: newRetryTransport(newLoggingTransport(transport, c.redactEmails), c.maxRetries, c.maxBackoff),
+		Timeout:   c.requestTimeout,
+	}, nil
+}
+
+// certPool returns the system certificate pool extended with the
+// certificates found in the configured CA bundle.
+func (c *config) certPool() (*x509.CertPool, error) {
+	pem, err := os.ReadFile(c.caBundleFile)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read ca_bundle_file: %s", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in ca_bundle_file %s", c.caBundleFile)
+	}
+	return pool, nil
+}
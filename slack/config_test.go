@@ -0,0 +1,72 @@
+package slack
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/require"
+)
+
+func testAuthTestServer(t *testing.T, newServer func(http.Handler) *httptest.Server) *httptest.Server {
+	server := newServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/auth.test" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeSlackResponse(w, `{"ok": true, "user_id": "U0123456789", "team_id": "T0123456789"}`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestConfigEndpoint(t *testing.T) {
+	require.Equal(t, slack.APIURL, (&config{}).endpoint())
+	require.Equal(t, "http://localhost:8080/api/", (&config{apiURL: "http://localhost:8080/api"}).endpoint())
+	require.Equal(t, "http://localhost:8080/api/", (&config{apiURL: "http://localhost:8080/api/"}).endpoint())
+}
+
+func TestConfigClient(t *testing.T) {
+	t.Run("uses the configured api url", func(t *testing.T) {
+		server := testAuthTestServer(t, httptest.NewServer)
+
+		client, err := newAPIClient(&config{apiURL: server.URL + "/api", maxBackoff: time.Second}, "xoxb-test")
+		require.NoError(t, err)
+
+		resp, err := client.AuthTest()
+		require.NoError(t, err)
+		require.Equal(t, "U0123456789", resp.UserID)
+	})
+
+	t.Run("trusts certificates from the ca bundle", func(t *testing.T) {
+		server := testAuthTestServer(t, httptest.NewTLSServer)
+
+		caBundleFile := filepath.Join(t.TempDir(), "ca.pem")
+		caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		require.NoError(t, os.WriteFile(caBundleFile, caBundle, 0o600))
+
+		client, err := newAPIClient(&config{apiURL: server.URL + "/api", caBundleFile: caBundleFile}, "xoxb-test")
+		require.NoError(t, err)
+
+		_, err = client.AuthTest()
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects a ca bundle without certificates", func(t *testing.T) {
+		caBundleFile := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(caBundleFile, []byte("not a certificate"), 0o600))
+
+		_, err := newAPIClient(&config{caBundleFile: caBundleFile}, "xoxb-test")
+		require.ErrorContains(t, err, "no certificates found")
+	})
+
+	t.Run("rejects an invalid proxy url", func(t *testing.T) {
+		_, err := newAPIClient(&config{httpProxy: "://proxy"}, "xoxb-test")
+		require.ErrorContains(t, err, "invalid http_proxy")
+	})
+}
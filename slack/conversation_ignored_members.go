@@ -0,0 +1,75 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/slack-go/slack"
+)
+
+// ignoredMembers are the members of a conversation that are never kicked,
+// and whose membership doesn't show up as drift: the listed users and,
+// optionally, the bots and the members of other organizations.
+type ignoredMembers struct {
+	userIDs  []string
+	bots     bool
+	external bool
+}
+
+func expandIgnoredMembers(d *schema.ResourceData) ignoredMembers {
+	return ignoredMembers{
+		userIDs:  schemaSetToSlice(d.Get("ignored_members").(*schema.Set)),
+		bots:     d.Get("ignore_bots").(bool),
+		external: d.Get("ignore_external_members").(bool),
+	}
+}
+
+// ignored returns which of the given users are ignored. Bots and external
+// members are told apart with users.info, which is only called when they are
+// ignored.
+func (i ignoredMembers) ignored(ctx context.Context, client *apiClient, userIDs []string) (map[string]bool, error) {
+	ignored := map[string]bool{}
+	for _, userID := range i.userIDs {
+		ignored[userID] = true
+	}
+	if !i.bots && !i.external {
+		return ignored, nil
+	}
+
+	auth, err := client.identity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for n, userID := range userIDs {
+		if ignored[userID] {
+			continue
+		}
+		user, err := client.GetUserInfoContext(ctx, userID)
+		if err != nil {
+			return nil, withProgress(ctx, fmt.Errorf("couldn't get user info for %s: %w", userID, err),
+				"looking up %d of %d members", n, len(userIDs))
+		}
+		if (i.bots && isBotUser(user)) || (i.external && isExternalUser(user, auth)) {
+			ignored[userID] = true
+		}
+	}
+	return ignored, nil
+}
+
+func isBotUser(user *slack.User) bool {
+	return user.IsBot || user.IsAppUser
+}
+
+// isExternalUser reports whether a user belongs to another organization than
+// the API user, e.g. a member of a Slack Connect channel. The users of the
+// other workspaces of an Enterprise Grid org aren't external.
+func isExternalUser(user *slack.User, auth *authInfo) bool {
+	switch {
+	case user.IsStranger:
+		return true
+	case auth.enterpriseID != "":
+		return user.Enterprise.EnterpriseID != auth.enterpriseID
+	}
+	return user.TeamID != auth.teamID
+}
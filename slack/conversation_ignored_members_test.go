@@ -0,0 +1,75 @@
+package slack
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsExternalUser(t *testing.T) {
+	auth := &authInfo{teamID: "T0HOME"}
+	grid := &authInfo{teamID: "T0HOME", enterpriseID: "E0HOME"}
+
+	tests := []struct {
+		name     string
+		user     slack.User
+		auth     *authInfo
+		external bool
+	}{
+		{name: "same workspace", user: slack.User{TeamID: "T0HOME"}, auth: auth},
+		{name: "other workspace", user: slack.User{TeamID: "T0OTHER"}, auth: auth, external: true},
+		{name: "stranger", user: slack.User{TeamID: "T0HOME", IsStranger: true}, auth: auth, external: true},
+		{name: "same org", user: slack.User{TeamID: "T0OTHER", Enterprise: slack.EnterpriseUser{EnterpriseID: "E0HOME"}}, auth: grid},
+		{name: "other org", user: slack.User{TeamID: "T0OTHER", Enterprise: slack.EnterpriseUser{EnterpriseID: "E0OTHER"}}, auth: grid, external: true},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.external, isExternalUser(&test.user, test.auth), test.name)
+	}
+}
+
+func TestConversationIgnoredMembers(t *testing.T) {
+	fake := testFakeSlack(t)
+	meta := fake.providerMeta(t)
+	client, err := meta.botClient()
+	require.NoError(t, err)
+
+	bot := fake.addUser("", "integration-bot", "")
+	fake.updateUser(bot, func(u *slack.User) { u.IsBot = true })
+	external := fake.addUser("", "partner", "partner@example.com")
+	fake.updateUser(external, func(u *slack.User) { u.TeamID = "T0PARTNER" })
+	id := fake.addChannel("partners", false, testUser00.id, testUser01.id, bot, external)
+
+	d := schema.TestResourceDataRaw(t, resourceSlackConversation().Schema, map[string]interface{}{
+		"name":                               "partners",
+		"is_private":                         false,
+		"permanent_members":                  []interface{}{testUserCreator.id},
+		"action_on_update_permanent_members": conversationActionOnUpdatePermanentMembersKick,
+		"ignored_members":                    []interface{}{testUser01.id},
+		"ignore_bots":                        true,
+		"ignore_external_members":            true,
+	})
+	d.SetId(id)
+
+	diags := resourceSlackConversationRead(context.Background(), d, meta)
+	require.False(t, diags.HasError(), "%v", diags)
+	require.ElementsMatch(t, []string{testUserCreator.id, testUser00.id}, schemaSetToSlice(d.Get("permanent_members").(*schema.Set)),
+		"only the members that would be kicked show up as drift")
+	require.Equal(t, 3, fake.callCount("users.info"), "the listed users aren't looked up")
+
+	require.NoError(t, d.Set("permanent_members", []string{}))
+	require.NoError(t, updateChannelMembers(context.Background(), d, meta, client, id))
+	channel, _ := fake.channel(id)
+	require.ElementsMatch(t, []string{testUserCreator.id, testUser01.id, bot, external}, channel.Members)
+	require.Equal(t, 1, fake.callCount("conversations.kick"))
+
+	t.Run("looks nobody up when bots and external members are kicked", func(t *testing.T) {
+		calls := fake.callCount("users.info")
+		ignored, err := ignoredMembers{userIDs: []string{bot}}.ignored(context.Background(), client, []string{bot, external})
+		require.NoError(t, err)
+		require.Equal(t, map[string]bool{bot: true}, ignored)
+		require.Equal(t, calls, fake.callCount("users.info"))
+	})
+}
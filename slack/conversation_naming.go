@@ -0,0 +1,124 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// conversationNameMaxLength is the maximum length of a channel name accepted
+// by Slack.
+const conversationNameMaxLength = 80
+
+func conversationNamingSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "The naming policy the names of the slack_conversation resources are checked against at plan time",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"pattern": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringIsValidRegExp,
+					Description:  "A regular expression the names must match",
+				},
+				"required_prefixes": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "The names must start with one of these prefixes",
+				},
+				"reserved_names": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Set:         schema.HashString,
+					Description: "Names that can't be used",
+				},
+			},
+		},
+	}
+}
+
+// conversationNaming is the naming policy of the conversations, on top of
+// the rules enforced by Slack.
+type conversationNaming struct {
+	pattern          *regexp.Regexp
+	requiredPrefixes []string
+	reservedNames    []string
+}
+
+func newConversationNaming(blocks []interface{}) (*conversationNaming, error) {
+	naming := &conversationNaming{}
+	if len(blocks) == 0 || blocks[0] == nil {
+		return naming, nil
+	}
+	block := blocks[0].(map[string]interface{})
+
+	if pattern := block["pattern"].(string); pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid conversation_naming pattern: %s", err)
+		}
+		naming.pattern = re
+	}
+	for _, prefix := range block["required_prefixes"].([]interface{}) {
+		naming.requiredPrefixes = append(naming.requiredPrefixes, prefix.(string))
+	}
+	naming.reservedNames = schemaSetToSlice(block["reserved_names"].(*schema.Set))
+	return naming, nil
+}
+
+// validate returns an error describing why name is not a valid conversation
+// name, according to Slack's rules and then to the naming policy.
+func (n *conversationNaming) validate(name string) error {
+	switch {
+	case utf8.RuneCountInString(name) > conversationNameMaxLength:
+		return fmt.Errorf("conversation name %q is longer than %d characters", name, conversationNameMaxLength)
+	case strings.ToLower(name) != name:
+		return fmt.Errorf("conversation name %q must be lowercase", name)
+	case strings.ContainsAny(name, " ."):
+		return fmt.Errorf("conversation name %q must not contain spaces or periods", name)
+	}
+
+	if n == nil {
+		return nil
+	}
+	if contains(n.reservedNames, name) {
+		return fmt.Errorf("conversation name %q is reserved by the conversation_naming policy of the provider", name)
+	}
+	if len(n.requiredPrefixes) > 0 && !hasAnyPrefix(name, n.requiredPrefixes) {
+		return fmt.Errorf("conversation name %q must start with one of %s, as required by the conversation_naming policy of the provider",
+			name, strings.Join(n.requiredPrefixes, ", "))
+	}
+	if n.pattern != nil && !n.pattern.MatchString(name) {
+		return fmt.Errorf("conversation name %q does not match the pattern %s of the conversation_naming policy of the provider", name, n.pattern)
+	}
+	return nil
+}
+
+// customizeDiffConversationName fails the plan of conversations whose new
+// name breaks the naming rules. Existing conversations are only checked when
+// they are renamed, so that adopting a policy doesn't block unrelated changes.
+func customizeDiffConversationName(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("name") || (d.Id() != "" && !d.HasChange("name")) {
+		return nil
+	}
+	return m.(*providerMeta).conversationNaming.validate(d.Get("name").(string))
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
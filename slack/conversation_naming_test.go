@@ -0,0 +1,95 @@
+package slack
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConversationNamingValidate(t *testing.T) {
+	raw := map[string]interface{}{
+		"conversation_naming": []interface{}{map[string]interface{}{
+			"pattern":           "^[a-z]+-[a-z0-9-]+$",
+			"required_prefixes": []interface{}{"eng-", "ops-"},
+			"reserved_names":    []interface{}{"eng-general"},
+		}},
+	}
+	d := schema.TestResourceDataRaw(t, Provider().Schema, raw)
+	policy, err := newConversationNaming(d.Get("conversation_naming").([]interface{}))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		policy *conversationNaming
+		error  string
+	}{
+		{name: "general", policy: nil},
+		{name: strings.Repeat("a", conversationNameMaxLength), policy: nil},
+		{name: strings.Repeat("a", conversationNameMaxLength+1), policy: nil, error: "is longer than 80 characters"},
+		{name: strings.Repeat("é", conversationNameMaxLength), policy: nil},
+		{name: strings.Repeat("é", conversationNameMaxLength+1), policy: nil, error: "is longer than 80 characters"},
+		{name: "General", policy: nil, error: "must be lowercase"},
+		{name: "my channel", policy: nil, error: "must not contain spaces or periods"},
+		{name: "v1.2", policy: nil, error: "must not contain spaces or periods"},
+		{name: "eng-builds", policy: policy},
+		{name: "Eng-builds", policy: policy, error: "must be lowercase"},
+		{name: "tmp-builds", policy: policy, error: "must start with one of eng-, ops-"},
+		{name: "eng-general", policy: policy, error: "is reserved"},
+		{name: "ops-", policy: policy, error: "does not match the pattern"},
+	}
+	for _, test := range tests {
+		err := test.policy.validate(test.name)
+		if test.error == "" {
+			require.NoError(t, err, test.name)
+		} else {
+			require.ErrorContains(t, err, test.error, test.name)
+		}
+	}
+}
+
+func TestAccSlackConversationNaming(t *testing.T) {
+	config := `
+provider slack {
+  conversation_naming {
+    required_prefixes = ["eng-"]
+    reserved_names    = ["eng-general"]
+  }
+}
+
+resource slack_conversation test {
+  name       = "%s"
+  is_private = false
+}
+`
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		// The policy is part of the provider configuration, so the provider
+		// can't be shared with the other tests.
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"slack": func() (*schema.Provider, error) { return Provider(), nil },
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(config, "tmp-builds"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`must start with one of eng-`),
+			},
+			{
+				Config:      fmt.Sprintf(config, "eng-general"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`is reserved`),
+			},
+			{
+				Config:      fmt.Sprintf(config, "eng builds"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`must not contain spaces or periods`),
+			},
+		},
+	})
+}
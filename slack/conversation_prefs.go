@@ -0,0 +1,197 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The values of who_can_post and who_can_reply in posting_permissions.
+const (
+	postingPermissionEveryone = "everyone"
+	postingPermissionAdmins   = "admins"
+	postingPermissionListed   = "listed"
+)
+
+// The conversation prefs of admin.conversations.setConversationPrefs, and
+// the arguments of posting_permissions they are managed with.
+var postingPermissionPrefs = map[string]string{
+	"who_can_post":  "who_can_post",
+	"who_can_reply": "can_thread",
+}
+
+var validatePostingPermission = validation.StringInSlice([]string{
+	postingPermissionEveryone,
+	postingPermissionAdmins,
+	postingPermissionListed,
+}, false)
+
+func postingPermissionsSchema() *schema.Schema {
+	fields := map[string]*schema.Schema{}
+	for argument := range postingPermissionPrefs {
+		fields[argument] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      postingPermissionEveryone,
+			ValidateFunc: validatePostingPermission,
+			Description:  "Either of everyone, admins (the admins and the listed users and usergroups) or listed (only the listed users and usergroups)",
+		}
+		fields[argument+"_users"] = &schema.Schema{
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Set:      schema.HashString,
+		}
+		fields[argument+"_usergroups"] = &schema.Schema{
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Set:      schema.HashString,
+		}
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Who can post and reply in threads in the conversation. Managed with the admin_token of the provider",
+		Elem:        &schema.Resource{Schema: fields},
+	}
+}
+
+// customizeDiffPostingPermissions fails the plan of posting_permissions that
+// list users or usergroups along with everyone, or only list nobody.
+func customizeDiffPostingPermissions(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("posting_permissions") {
+		return nil
+	}
+	_, err := expandPostingPermissions(d.Get("posting_permissions").([]interface{}))
+	return err
+}
+
+// conversationPref is a conversation pref of the admin APIs: the types of
+// users, the users and the usergroups (subteams) allowed to do something.
+// Nothing allowed means that everyone is.
+type conversationPref struct {
+	Type    []string `json:"type"`
+	User    []string `json:"user"`
+	Subteam []string `json:"subteam"`
+}
+
+// String returns the pref as expected by setConversationPrefs, e.g.
+// type:admin,user:U0123,subteam:S0456.
+func (p conversationPref) String() string {
+	var values []string
+	for _, t := range p.Type {
+		values = append(values, "type:"+t)
+	}
+	for _, u := range p.User {
+		values = append(values, "user:"+u)
+	}
+	for _, s := range p.Subteam {
+		values = append(values, "subteam:"+s)
+	}
+	return strings.Join(values, ",")
+}
+
+// expandPostingPermissions returns the conversation prefs matching the
+// posting_permissions block. No block allows everyone to post and reply.
+func expandPostingPermissions(blocks []interface{}) (map[string]conversationPref, error) {
+	block := map[string]interface{}{}
+	if len(blocks) > 0 && blocks[0] != nil {
+		block = blocks[0].(map[string]interface{})
+	}
+
+	prefs := map[string]conversationPref{}
+	for argument, name := range postingPermissionPrefs {
+		permission, _ := block[argument].(string)
+		if permission == "" {
+			permission = postingPermissionEveryone
+		}
+		var pref conversationPref
+		if users, ok := block[argument+"_users"].(*schema.Set); ok {
+			pref.User = schemaSetToSlice(users)
+		}
+		if usergroups, ok := block[argument+"_usergroups"].(*schema.Set); ok {
+			pref.Subteam = schemaSetToSlice(usergroups)
+		}
+		sort.Strings(pref.User)
+		sort.Strings(pref.Subteam)
+
+		listed := len(pref.User) > 0 || len(pref.Subteam) > 0
+		switch {
+		case permission == postingPermissionEveryone && listed:
+			return nil, fmt.Errorf("posting_permissions: %s_users and %s_usergroups can't be set when %s is %s", argument, argument, argument, postingPermissionEveryone)
+		case permission == postingPermissionListed && !listed:
+			return nil, fmt.Errorf("posting_permissions: %s_users or %s_usergroups must be set when %s is %s", argument, argument, argument, postingPermissionListed)
+		case permission == postingPermissionAdmins:
+			pref.Type = []string{"admin"}
+		}
+		prefs[name] = pref
+	}
+	return prefs, nil
+}
+
+// flattenPostingPermissions returns the posting_permissions block matching
+// the conversation prefs.
+func flattenPostingPermissions(prefs map[string]conversationPref) []interface{} {
+	block := map[string]interface{}{}
+	for argument, name := range postingPermissionPrefs {
+		pref := prefs[name]
+		permission := postingPermissionEveryone
+		switch {
+		case contains(pref.Type, "admin"):
+			permission = postingPermissionAdmins
+		case len(pref.User) > 0 || len(pref.Subteam) > 0:
+			permission = postingPermissionListed
+		}
+		block[argument] = permission
+		block[argument+"_users"] = pref.User
+		block[argument+"_usergroups"] = pref.Subteam
+	}
+	return []interface{}{block}
+}
+
+func setConversationPrefs(ctx context.Context, m *providerMeta, channelID string, prefs map[string]conversationPref) error {
+	client, err := m.adminClient()
+	if err != nil {
+		return err
+	}
+
+	values := map[string]string{}
+	for name, pref := range prefs {
+		values[name] = pref.String()
+	}
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	if _, err := client.call(ctx, "admin.conversations.setConversationPrefs", url.Values{
+		"channel_id": {channelID},
+		"prefs":      {string(encoded)},
+	}, nil); err != nil {
+		return fmt.Errorf("couldn't set the prefs of conversation %s: %w", channelID, err)
+	}
+	return nil
+}
+
+func getConversationPrefs(ctx context.Context, m *providerMeta, channelID string) (map[string]conversationPref, error) {
+	client, err := m.adminClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Prefs map[string]conversationPref `json:"prefs"`
+	}
+	if _, err := client.call(ctx, "admin.conversations.getConversationPrefs", url.Values{"channel_id": {channelID}}, &resp); err != nil {
+		return nil, fmt.Errorf("couldn't get the prefs of conversation %s: %w", channelID, err)
+	}
+	return resp.Prefs, nil
+}
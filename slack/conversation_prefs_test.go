@@ -0,0 +1,137 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandPostingPermissions(t *testing.T) {
+	expand := func(raw map[string]interface{}) (map[string]conversationPref, error) {
+		d := schema.TestResourceDataRaw(t, resourceSlackConversation().Schema, map[string]interface{}{
+			"name":                "prefs",
+			"is_private":          false,
+			"posting_permissions": []interface{}{raw},
+		})
+		return expandPostingPermissions(d.Get("posting_permissions").([]interface{}))
+	}
+
+	prefs, err := expandPostingPermissions(nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]conversationPref{"who_can_post": {}, "can_thread": {}}, prefs, "everyone by default")
+
+	prefs, err = expand(map[string]interface{}{
+		"who_can_post":            postingPermissionAdmins,
+		"who_can_post_usergroups": []interface{}{"S0002", "S0001"},
+		"who_can_reply":           postingPermissionListed,
+		"who_can_reply_users":     []interface{}{"U0001"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "type:admin,subteam:S0001,subteam:S0002", prefs["who_can_post"].String())
+	require.Equal(t, "user:U0001", prefs["can_thread"].String())
+	require.Equal(t, []interface{}{map[string]interface{}{
+		"who_can_post":             postingPermissionAdmins,
+		"who_can_post_users":       []string{},
+		"who_can_post_usergroups":  []string{"S0001", "S0002"},
+		"who_can_reply":            postingPermissionListed,
+		"who_can_reply_users":      []string{"U0001"},
+		"who_can_reply_usergroups": []string{},
+	}}, flattenPostingPermissions(prefs))
+
+	_, err = expand(map[string]interface{}{"who_can_post_users": []interface{}{"U0001"}})
+	require.EqualError(t, err, "posting_permissions: who_can_post_users and who_can_post_usergroups can't be set when who_can_post is everyone")
+
+	_, err = expand(map[string]interface{}{"who_can_reply": postingPermissionListed})
+	require.EqualError(t, err, "posting_permissions: who_can_reply_users or who_can_reply_usergroups must be set when who_can_reply is listed")
+}
+
+func TestConversationPostingPermissions(t *testing.T) {
+	fake := testFakeSlack(t)
+	meta := fake.providerMeta(t)
+
+	d := schema.TestResourceDataRaw(t, resourceSlackConversation().Schema, map[string]interface{}{
+		"name":       "announcements",
+		"is_private": false,
+		"posting_permissions": []interface{}{map[string]interface{}{
+			"who_can_post":        postingPermissionListed,
+			"who_can_post_users":  []interface{}{testUser00.id},
+			"who_can_reply":       postingPermissionAdmins,
+			"who_can_reply_users": []interface{}{testUser01.id},
+		}},
+	})
+	diags := resourceSlackConversationCreate(context.Background(), d, meta)
+	require.False(t, diags.HasError(), "%v", diags)
+	require.Equal(t, map[string]conversationPref{
+		"who_can_post": {User: []string{testUser00.id}},
+		"can_thread":   {Type: []string{"admin"}, User: []string{testUser01.id}},
+	}, fake.channelPrefs[d.Id()])
+
+	// changed in the UI
+	fake.channelPrefs[d.Id()]["who_can_post"] = conversationPref{Type: []string{"admin"}}
+	diags = resourceSlackConversationRead(context.Background(), d, meta)
+	require.False(t, diags.HasError(), "%v", diags)
+	require.Equal(t, postingPermissionAdmins, d.Get("posting_permissions.0.who_can_post"))
+	require.Equal(t, 0, d.Get("posting_permissions.0.who_can_post_users").(*schema.Set).Len())
+}
+
+func TestAccSlackConversationPostingPermissions(t *testing.T) {
+	var providers []*schema.Provider
+	name := testAccRandomName(t, conversationNamePrefix)
+	resourceName := fmt.Sprintf("slack_conversation.%s", name)
+	config := func(postingPermissions string) string {
+		return fmt.Sprintf(`
+resource slack_conversation %s {
+  name       = "%s"
+  is_private = false
+%s
+}
+`, name, name, postingPermissions)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckAdmin(t) },
+		ProviderFactories: testAccProviderFactories(&providers),
+		CheckDestroy:      testAccCheckConversationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(fmt.Sprintf(`
+  posting_permissions {
+    who_can_post        = "admins"
+    who_can_post_users  = ["%s"]
+    who_can_reply       = "listed"
+    who_can_reply_users = ["%s", "%s"]
+  }`, testUser00.id, testUser00.id, testUser01.id)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "posting_permissions.0.who_can_post", "admins"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "posting_permissions.0.who_can_post_users.*", testUser00.id),
+					resource.TestCheckResourceAttr(resourceName, "posting_permissions.0.who_can_reply", "listed"),
+					resource.TestCheckResourceAttr(resourceName, "posting_permissions.0.who_can_reply_users.#", "2"),
+				),
+			},
+			{
+				Config: config(`
+  posting_permissions {
+    who_can_post = "admins"
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "posting_permissions.0.who_can_post", "admins"),
+					resource.TestCheckResourceAttr(resourceName, "posting_permissions.0.who_can_post_users.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "posting_permissions.0.who_can_reply", "everyone"),
+				),
+			},
+			{
+				Config: config(`
+  posting_permissions {
+    who_can_reply = "listed"
+  }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`who_can_reply_users or who_can_reply_usergroups must be set`),
+			},
+		},
+	})
+}
@@ -0,0 +1,126 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// customizeDiffConversationTeam plans the replacement of conversations that
+// are moved to another workspace, unless they can be moved in place with the
+// admin APIs.
+func customizeDiffConversationTeam(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("team_id") {
+		return nil
+	}
+	if m.(*providerMeta).hasAdminAPIs() {
+		tflog.Info(ctx, "conversation will be moved in place", map[string]interface{}{
+			"channel": d.Id(),
+			"team_id": d.Get("team_id"),
+		})
+		return nil
+	}
+	return d.ForceNew("team_id")
+}
+
+// customizeDiffConversationTeams fails the plan of team_ids that don't list
+// the workspace of the conversation, which Slack always lists, and the removal
+// of team_ids when the workspace of the conversation isn't known.
+func customizeDiffConversationTeams(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("team_ids") || !d.NewValueKnown("team_id") {
+		return nil
+	}
+	teamIDs := schemaSetToSlice(d.Get("team_ids").(*schema.Set))
+	teamID := d.Get("team_id").(string)
+	if teamID == "" {
+		teamID = m.(*providerMeta).teamID
+	}
+	if len(teamIDs) == 0 {
+		if d.Id() != "" && d.HasChange("team_ids") && teamID == "" {
+			return fmt.Errorf("removing team_ids shares conversation %s with its own workspace, which must be set in the team_id of the resource or of the provider", d.Id())
+		}
+		return nil
+	}
+	if teamID != "" && !contains(teamIDs, teamID) {
+		return fmt.Errorf("team_ids must include the workspace of the conversation, %s", teamID)
+	}
+	return nil
+}
+
+// moveConversation moves a conversation to another workspace of an
+// Enterprise Grid org with the admin APIs. The move is done by Slack in the
+// background.
+func moveConversation(ctx context.Context, m *providerMeta, channelID, teamID string) error {
+	client, err := m.adminClient()
+	if err != nil {
+		return err
+	}
+	if _, err := client.call(ctx, "admin.conversations.bulkMove", url.Values{
+		"channel_ids":    {channelID},
+		"target_team_id": {teamID},
+	}, nil); err != nil {
+		return fmt.Errorf("couldn't move conversation %s to %s: %w", channelID, teamID, err)
+	}
+	return nil
+}
+
+// setConversationTeams sets the workspaces of an Enterprise Grid org the
+// conversation of the workspace teamID is shared with.
+func setConversationTeams(ctx context.Context, m *providerMeta, channelID, teamID string, teamIDs []string) error {
+	client, err := m.adminClient()
+	if err != nil {
+		return err
+	}
+	values := url.Values{
+		"channel_id":      {channelID},
+		"target_team_ids": {strings.Join(teamIDs, ",")},
+	}
+	if teamID != "" {
+		values.Set("team_id", teamID)
+	}
+	if _, err := client.call(ctx, "admin.conversations.setTeams", values, nil); err != nil {
+		return fmt.Errorf("couldn't set the workspaces of conversation %s: %w", channelID, err)
+	}
+	return nil
+}
+
+// getConversationTeams returns the workspaces of an Enterprise Grid org the
+// conversation is shared with, going through every page of
+// admin.conversations.getTeams.
+func getConversationTeams(ctx context.Context, m *providerMeta, channelID string) ([]string, error) {
+	client, err := m.adminClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var teamIDs []string
+	cursor := ""
+	for {
+		var resp struct {
+			TeamIDs          []string `json:"team_ids"`
+			ResponseMetadata struct {
+				NextCursor string `json:"next_cursor"`
+			} `json:"response_metadata"`
+		}
+		values := url.Values{
+			"channel_id": {channelID},
+			"limit":      {strconv.Itoa(cursorLimit)},
+		}
+		if cursor != "" {
+			values.Set("cursor", cursor)
+		}
+		if _, err := client.call(ctx, "admin.conversations.getTeams", values, &resp); err != nil {
+			return nil, fmt.Errorf("couldn't get the workspaces of conversation %s: %w", channelID, err)
+		}
+		teamIDs = append(teamIDs, resp.TeamIDs...)
+		if resp.ResponseMetadata.NextCursor == "" {
+			return teamIDs, nil
+		}
+		cursor = resp.ResponseMetadata.NextCursor
+	}
+}
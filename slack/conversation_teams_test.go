@@ -0,0 +1,169 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConversationTeams(t *testing.T) {
+	fake := testFakeSlack(t)
+	meta := fake.providerMeta(t)
+	meta.teamID = "T0GRIDTEAM"
+	meta.clients[tokenAdmin] = meta.clients[tokenDefault]
+
+	d := schema.TestResourceDataRaw(t, resourceSlackConversation().Schema, map[string]interface{}{
+		"name":       "grid-shared",
+		"is_private": false,
+		"team_ids":   []interface{}{"T0GRIDTEAM", "T0OTHERTEAM"},
+	})
+	diags := resourceSlackConversationCreate(context.Background(), d, meta)
+	require.False(t, diags.HasError(), "%v", diags)
+	require.ElementsMatch(t, []string{"T0GRIDTEAM", "T0OTHERTEAM"}, fake.orgTeams[d.Id()])
+
+	// unshared in the admin UI
+	fake.orgTeams[d.Id()] = []string{"T0GRIDTEAM"}
+	diags = resourceSlackConversationRead(context.Background(), d, meta)
+	require.False(t, diags.HasError(), "%v", diags)
+	require.Equal(t, []string{"T0GRIDTEAM"}, schemaSetToSlice(d.Get("team_ids").(*schema.Set)))
+
+	require.NoError(t, moveConversation(context.Background(), meta, d.Id(), "T0OTHERTEAM"))
+	require.Equal(t, "T0OTHERTEAM", fake.channelTeams[d.Id()])
+	teamIDs, err := getConversationTeams(context.Background(), meta, d.Id())
+	require.NoError(t, err)
+	require.Equal(t, []string{"T0OTHERTEAM"}, teamIDs)
+
+	err = setConversationTeams(context.Background(), meta, d.Id(), "T0GRIDTEAM", []string{"T0GRIDTEAM"})
+	require.EqualError(t, err, fmt.Sprintf("couldn't set the workspaces of conversation %s: team_not_found", d.Id()))
+}
+
+func TestConversationTeamDiff(t *testing.T) {
+	fake := testFakeSlack(t)
+	id := fake.addChannel("grid-moved", false)
+
+	diff := func(t *testing.T, meta *providerMeta, config map[string]interface{}) (*terraform.InstanceDiff, error) {
+		state := &terraform.InstanceState{
+			ID: id,
+			Attributes: map[string]string{
+				"id":                                 id,
+				"name":                               "grid-moved",
+				"is_private":                         "false",
+				"is_archived":                        "false",
+				"action_on_destroy":                  conversationActionOnDestroyArchive,
+				"action_on_update_permanent_members": conversationActionOnUpdatePermanentMembersKick,
+				"adopt_existing_channel":             "false",
+				"team_id":                            "T0GRIDTEAM",
+			},
+		}
+		config["name"] = "grid-moved"
+		config["is_private"] = false
+		return resourceSlackConversation().SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+	}
+
+	t.Run("replaced without the admin scopes", func(t *testing.T) {
+		d, err := diff(t, fake.providerMetaWithoutAdmin(t), map[string]interface{}{"team_id": "T0OTHERTEAM"})
+		require.NoError(t, err)
+		require.True(t, d.Attributes["team_id"].RequiresNew)
+	})
+
+	t.Run("moved in place with the admin scopes", func(t *testing.T) {
+		d, err := diff(t, fake.providerMeta(t), map[string]interface{}{"team_id": "T0OTHERTEAM"})
+		require.NoError(t, err)
+		require.Equal(t, "T0OTHERTEAM", d.Attributes["team_id"].New)
+		require.False(t, d.Attributes["team_id"].RequiresNew)
+	})
+
+	t.Run("team_ids must include the workspace", func(t *testing.T) {
+		_, err := diff(t, fake.providerMeta(t), map[string]interface{}{
+			"team_id":  "T0GRIDTEAM",
+			"team_ids": []interface{}{"T0OTHERTEAM"},
+		})
+		require.ErrorContains(t, err, "team_ids must include the workspace of the conversation, T0GRIDTEAM")
+	})
+
+	t.Run("team_ids can't be removed without the workspace", func(t *testing.T) {
+		state := &terraform.InstanceState{
+			ID: id,
+			Attributes: map[string]string{
+				"id":                                 id,
+				"name":                               "grid-moved",
+				"is_private":                         "false",
+				"is_archived":                        "false",
+				"action_on_destroy":                  conversationActionOnDestroyArchive,
+				"action_on_update_permanent_members": conversationActionOnUpdatePermanentMembersKick,
+				"adopt_existing_channel":             "false",
+				"team_ids.#":                         "2",
+			},
+		}
+		for _, teamID := range []string{"T0GRIDTEAM", "T0OTHERTEAM"} {
+			state.Attributes[fmt.Sprintf("team_ids.%d", schema.HashString(teamID))] = teamID
+		}
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":       "grid-moved",
+			"is_private": false,
+		})
+		meta := fake.providerMeta(t)
+		_, err := resourceSlackConversation().SimpleDiff(context.Background(), state, config, meta)
+		require.ErrorContains(t, err, fmt.Sprintf("removing team_ids shares conversation %s with its own workspace, which must be set in the team_id of the resource or of the provider", id))
+
+		meta.teamID = "T0GRIDTEAM"
+		d, err := resourceSlackConversation().SimpleDiff(context.Background(), state, config, meta)
+		require.NoError(t, err)
+		require.Equal(t, "0", d.Attributes["team_ids.#"].New)
+	})
+}
+
+func TestConversationTeamsAfterMove(t *testing.T) {
+	fake := testFakeSlack(t)
+	meta := fake.providerMeta(t)
+	meta.teamID = "T0GRIDTEAM"
+	meta.clients[tokenAdmin] = meta.clients[tokenDefault]
+	id := fake.addChannel("grid-moved", false)
+	fake.channelTeams[id] = "T0GRIDTEAM"
+
+	apply := func(t *testing.T, state *terraform.InstanceState, config map[string]interface{}) (*terraform.InstanceState, diag.Diagnostics) {
+		config["name"] = "grid-moved"
+		config["is_private"] = false
+		r := resourceSlackConversation()
+		diff, err := r.SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(config), meta)
+		require.NoError(t, err)
+		newState, diags := r.Apply(context.Background(), state, diff, meta)
+		require.False(t, diags.HasError(), "%v", diags)
+		return newState, diags
+	}
+	state := &terraform.InstanceState{
+		ID: id,
+		Attributes: map[string]string{
+			"id":                                 id,
+			"name":                               "grid-moved",
+			"is_private":                         "false",
+			"is_archived":                        "false",
+			"action_on_destroy":                  conversationActionOnDestroyArchive,
+			"action_on_update_permanent_members": conversationActionOnUpdatePermanentMembersKick,
+			"adopt_existing_channel":             "false",
+			"team_id":                            "T0GRIDTEAM",
+		},
+	}
+
+	config := func() map[string]interface{} {
+		return map[string]interface{}{
+			"team_id":  "T0OTHERTEAM",
+			"team_ids": []interface{}{"T0OTHERTEAM", "T0GRIDTEAM"},
+		}
+	}
+	state, diags := apply(t, state, config())
+	require.Equal(t, "T0OTHERTEAM", fake.channelTeams[id])
+	require.Equal(t, 0, fake.callCount("admin.conversations.setTeams"), "the move isn't done yet")
+	require.Len(t, diags, 1)
+	require.Equal(t, diag.Warning, diags[0].Severity)
+	require.Equal(t, "0", state.Attributes["team_ids.#"], "the workspaces show up as drift")
+
+	_, diags = apply(t, state, config())
+	require.Empty(t, diags)
+	require.ElementsMatch(t, []string{"T0OTHERTEAM", "T0GRIDTEAM"}, fake.orgTeams[id])
+}
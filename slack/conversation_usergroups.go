@@ -0,0 +1,127 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/slack-go/slack"
+)
+
+// expandPermanentUsergroups returns the users of each of the
+// permanent_usergroups of a conversation, looked up with the user token of the
+// provider, and the usergroups that weren't found, e.g. because they were
+// disabled or deleted.
+func expandPermanentUsergroups(ctx context.Context, d *schema.ResourceData, m *providerMeta) (map[string][]string, []string, error) {
+	usergroupIDs := schemaSetToSlice(d.Get("permanent_usergroups").(*schema.Set))
+	users := make(map[string][]string, len(usergroupIDs))
+	if len(usergroupIDs) == 0 {
+		return users, nil, nil
+	}
+
+	client, err := m.userClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	userGroups, err := listUserGroups(ctx, client, m.resourceTeamID(d), false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't expand permanent_usergroups: %w", err)
+	}
+	byID := make(map[string]slack.UserGroup, len(userGroups))
+	for _, userGroup := range userGroups {
+		byID[userGroup.ID] = userGroup
+	}
+	var missing []string
+	for _, usergroupID := range usergroupIDs {
+		userGroup, ok := byID[usergroupID]
+		if !ok {
+			missing = append(missing, usergroupID)
+			continue
+		}
+		users[usergroupID] = userGroup.Users
+	}
+	return users, missing, nil
+}
+
+// uninvitableUsers returns which of the given users can't be invited to a
+// conversation: the deactivated users, the single-channel guests and the
+// users who aren't in the workspace teamID. The users who aren't members yet
+// are looked up with a single pass through users.list, rather than one call
+// of users.info each.
+func uninvitableUsers(ctx context.Context, client *apiClient, teamID string, userIDs, members []string) (map[string]bool, error) {
+	isMember := make(map[string]bool, len(members))
+	for _, userID := range members {
+		isMember[userID] = true
+	}
+	var nonMembers []string
+	for _, userID := range userIDs {
+		if !isMember[userID] {
+			nonMembers = append(nonMembers, userID)
+		}
+	}
+
+	uninvitable := map[string]bool{}
+	if len(nonMembers) == 0 {
+		return uninvitable, nil
+	}
+	options := []slack.GetUsersOption{slack.GetUsersOptionLimit(cursorLimit)}
+	if teamID != "" {
+		options = append(options, slack.GetUsersOptionTeamID(teamID))
+	}
+	users, err := client.GetUsersContext(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list the users of the workspace: %w", err)
+	}
+	byID := make(map[string]slack.User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+	for _, userID := range nonMembers {
+		user, ok := byID[userID]
+		if !ok || user.Deleted || user.IsUltraRestricted {
+			uninvitable[userID] = true
+		}
+	}
+	return uninvitable, nil
+}
+
+// usergroupMembers returns the users of all the usergroups, once.
+func usergroupMembers(usergroupUsers map[string][]string) []string {
+	var members []string
+	seen := map[string]bool{}
+	for _, users := range usergroupUsers {
+		for _, userID := range users {
+			if !seen[userID] {
+				seen[userID] = true
+				members = append(members, userID)
+			}
+		}
+	}
+	return members
+}
+
+// actualPermanentUsergroups returns the usergroups whose users are all members
+// of the conversation, but the uninvitable ones. The others show up as drift,
+// so that the users added to them since the last apply are invited by the next
+// one.
+func actualPermanentUsergroups(usergroupUsers map[string][]string, members []string, uninvitable map[string]bool) []string {
+	isMember := make(map[string]bool, len(members))
+	for _, userID := range members {
+		isMember[userID] = true
+	}
+
+	usergroupIDs := []string{}
+	for usergroupID, users := range usergroupUsers {
+		complete := true
+		for _, userID := range users {
+			if !isMember[userID] && !uninvitable[userID] {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			usergroupIDs = append(usergroupIDs, usergroupID)
+		}
+	}
+	return usergroupIDs
+}
@@ -0,0 +1,181 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActualPermanentUsergroups(t *testing.T) {
+	usergroupUsers := map[string][]string{
+		"S0ONCALL": {testUser00.id},
+		"S0SRE":    {testUser00.id, testUser01.id},
+		"S0EMPTY":  nil,
+	}
+	require.ElementsMatch(t, []string{"S0ONCALL", "S0EMPTY"}, actualPermanentUsergroups(usergroupUsers, []string{testUserCreator.id, testUser00.id}, nil))
+	require.ElementsMatch(t, []string{"S0ONCALL", "S0SRE", "S0EMPTY"}, actualPermanentUsergroups(usergroupUsers, []string{testUserCreator.id, testUser00.id}, map[string]bool{testUser01.id: true}),
+		"the uninvitable users aren't expected to be members")
+	require.ElementsMatch(t, []string{testUser00.id, testUser01.id}, usergroupMembers(usergroupUsers))
+}
+
+func TestConversationPermanentUsergroups(t *testing.T) {
+	fake := testFakeSlack(t)
+	meta := fake.providerMeta(t)
+	client, err := meta.userClient()
+	require.NoError(t, err)
+
+	userGroup, err := callUserGroup(context.Background(), client, "usergroups.create", "", url.Values{"name": {"platform-oncall"}})
+	require.NoError(t, err)
+	_, err = callUserGroup(context.Background(), client, "usergroups.users.update", "", url.Values{
+		"usergroup": {userGroup.ID},
+		"users":     {testUser00.id},
+	})
+	require.NoError(t, err)
+
+	d := schema.TestResourceDataRaw(t, resourceSlackConversation().Schema, map[string]interface{}{
+		"name":                 "incidents",
+		"is_private":           false,
+		"permanent_usergroups": []interface{}{userGroup.ID},
+	})
+	diags := resourceSlackConversationCreate(context.Background(), d, meta)
+	require.False(t, diags.HasError(), "%v", diags)
+	channel, _ := fake.channel(d.Id())
+	require.ElementsMatch(t, []string{testUserCreator.id, testUser00.id}, channel.Members)
+	require.Equal(t, 0, d.Get("permanent_members").(*schema.Set).Len(), "the users of the usergroups aren't drift")
+	require.Equal(t, []string{userGroup.ID}, schemaSetToSlice(d.Get("permanent_usergroups").(*schema.Set)))
+
+	// joined the usergroup after the apply
+	_, err = callUserGroup(context.Background(), client, "usergroups.users.update", "", url.Values{
+		"usergroup": {userGroup.ID},
+		"users":     {testUser00.id + "," + testUser01.id},
+	})
+	require.NoError(t, err)
+	diags = resourceSlackConversationRead(context.Background(), d, meta)
+	require.False(t, diags.HasError(), "%v", diags)
+	require.Equal(t, 0, d.Get("permanent_usergroups").(*schema.Set).Len(), "the usergroup shows up as drift")
+
+	require.NoError(t, d.Set("permanent_usergroups", []string{userGroup.ID}))
+	require.NoError(t, updateChannelMembers(context.Background(), d, meta, client, d.Id()))
+	channel, _ = fake.channel(d.Id())
+	require.ElementsMatch(t, []string{testUserCreator.id, testUser00.id, testUser01.id}, channel.Members)
+
+	t.Run("skips the uninvitable users", func(t *testing.T) {
+		deactivated := fake.addUser("", "former-employee", "")
+		fake.updateUser(deactivated, func(u *slack.User) { u.Deleted = true })
+		guest := fake.addUser("", "contractor", "")
+		fake.updateUser(guest, func(u *slack.User) { u.IsUltraRestricted = true })
+		_, err = callUserGroup(context.Background(), client, "usergroups.users.update", "", url.Values{
+			"usergroup": {userGroup.ID},
+			"users":     {strings.Join([]string{testUser00.id, testUser01.id, deactivated, guest}, ",")},
+		})
+		require.NoError(t, err)
+
+		lists, userLists, infos := fake.callCount("usergroups.list"), fake.callCount("users.list"), fake.callCount("users.info")
+		diags = resourceSlackConversationRead(context.Background(), d, meta)
+		require.False(t, diags.HasError(), "%v", diags)
+		require.Equal(t, []string{userGroup.ID}, schemaSetToSlice(d.Get("permanent_usergroups").(*schema.Set)), "no drift")
+		require.Equal(t, lists+1, fake.callCount("usergroups.list"))
+		require.Equal(t, userLists+1, fake.callCount("users.list"), "the non-members are looked up at once")
+		require.Equal(t, infos, fake.callCount("users.info"))
+
+		require.NoError(t, updateChannelMembers(context.Background(), d, meta, client, d.Id()))
+		channel, _ = fake.channel(d.Id())
+		require.ElementsMatch(t, []string{testUserCreator.id, testUser00.id, testUser01.id}, channel.Members)
+	})
+
+	t.Run("drops the disabled usergroups", func(t *testing.T) {
+		_, err := callUserGroup(context.Background(), client, "usergroups.disable", "", url.Values{"usergroup": {userGroup.ID}})
+		require.NoError(t, err)
+
+		diags := resourceSlackConversationRead(context.Background(), d, meta)
+		require.False(t, diags.HasError(), "%v", diags)
+		require.Len(t, diags, 1)
+		require.Equal(t, diag.Warning, diags[0].Severity)
+		require.Equal(t, fmt.Sprintf("usergroup %s of conversation %s not found, removing it from permanent_usergroups", userGroup.ID, d.Id()), diags[0].Summary)
+		require.Equal(t, 0, d.Get("permanent_usergroups").(*schema.Set).Len(), "the usergroup shows up as drift")
+
+		require.NoError(t, d.Set("permanent_usergroups", []string{userGroup.ID}))
+		err = updateChannelMembers(context.Background(), d, meta, client, d.Id())
+		require.EqualError(t, err, fmt.Sprintf("couldn't expand permanent_usergroups: could not find usergroups %s, they may be disabled or deleted", userGroup.ID))
+	})
+}
+
+func TestAccSlackConversationPermanentUsergroups(t *testing.T) {
+	var providers []*schema.Provider
+	name := testAccRandomName(t, conversationNamePrefix)
+	userGroupName := testAccRandomName(t, userGroupResourceNamePrefix)
+	resourceName := fmt.Sprintf("slack_conversation.%s", name)
+	var channelID, userGroupID string
+	config := fmt.Sprintf(`
+resource slack_usergroup oncall {
+  name   = "%s"
+  handle = "%s"
+  users  = ["%s"]
+
+  lifecycle {
+    ignore_changes = [users]
+  }
+}
+
+resource slack_conversation %s {
+  name                 = "%s"
+  is_private           = false
+  permanent_usergroups = [slack_usergroup.oncall.id]
+}
+`, userGroupName, userGroupName, testUser00.id, name, name)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(&providers),
+		CheckDestroy: func(s *terraform.State) error {
+			if err := testAccCheckConversationDestroy(s); err != nil {
+				return err
+			}
+			return testAccCheckUserGroupDestroy(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "permanent_usergroups.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "permanent_members.#", "0"),
+					func(s *terraform.State) error {
+						channelID = s.RootModule().Resources[resourceName].Primary.ID
+						userGroupID = s.RootModule().Resources["slack_usergroup.oncall"].Primary.ID
+						return testAccCheckConversationMembers(channelID, []string{testUser00.id}, []string{testUser01.id})(s)
+					},
+				),
+			},
+			{
+				// joined the usergroup outside of Terraform
+				PreConfig: func() {
+					client, err := testAccProvider.Meta().(*providerMeta).userClient()
+					require.NoError(t, err)
+					_, err = callUserGroup(context.Background(), client, "usergroups.users.update", "", url.Values{
+						"usergroup": {userGroupID},
+						"users":     {strings.Join([]string{testUser00.id, testUser01.id}, ",")},
+					})
+					require.NoError(t, err)
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: func(s *terraform.State) error {
+					return testAccCheckConversationMembers(channelID, []string{testUser00.id, testUser01.id}, nil)(s)
+				},
+			},
+		},
+	})
+}
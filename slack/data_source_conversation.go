@@ -26,6 +26,10 @@ func dataSourceConversation() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			"team_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 			"topic": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -62,18 +66,36 @@ func dataSourceConversation() *schema.Resource {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+			"members": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:      schema.HashString,
+				Computed: true,
+			},
 		},
 	}
 }
 
 func dataSourceSlackConversationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*slack.Client)
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	channelID := d.Get("channel_id").(string)
 	channelName := d.Get("name").(string)
 	isPrivate := d.Get("is_private").(bool)
 
+	scope := "channels:read"
+	if isPrivate {
+		scope = "groups:read"
+	}
+	if err := client.requireScopes("data.slack_conversation", scope); err != nil {
+		return diag.FromErr(err)
+	}
+
 	var channel *slack.Channel
-	var err error
 	if channelID != "" {
 		channel, err = client.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
 			ChannelID: channelID,
@@ -82,7 +104,7 @@ func dataSourceSlackConversationRead(ctx context.Context, d *schema.ResourceData
 			return diag.FromErr(fmt.Errorf("couldn't get conversation info for %s: %w", channelID, err))
 		}
 	} else if channelName != "" {
-		channel, err = findExistingChannel(ctx, client, channelName, isPrivate)
+		channel, err = findExistingChannel(ctx, client, channelName, isPrivate, m.(*providerMeta).resourceTeamID(d))
 		if err != nil {
 			return diag.FromErr(fmt.Errorf("couldn't get conversation info for %s: %w", channelName, err))
 		}
@@ -90,9 +112,7 @@ func dataSourceSlackConversationRead(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(fmt.Errorf("channel_id or name must be set"))
 	}
 
-	users, _, err := client.GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
-		ChannelID: channel.ID,
-	})
+	users, err := getConversationMembers(ctx, client, channel.ID)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("couldn't get users in conversation for %s: %w", channel.ID, err))
 	}
@@ -31,7 +31,13 @@ func dataSourceUser() *schema.Resource {
 func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	client := m.(*slack.Client)
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := client.requireScopes("data.slack_user", "users:read"); err != nil {
+		return diag.FromErr(err)
+	}
 
 	var user *slack.User
 	if name, ok := d.GetOk("name"); ok {
@@ -43,6 +49,9 @@ func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, m interface
 	}
 
 	if email, ok := d.GetOk("email"); ok {
+		if err := client.requireScopes("data.slack_user", "users:read.email"); err != nil {
+			return diag.FromErr(err)
+		}
 		u, err := client.GetUserByEmailContext(ctx, email.(string))
 		if err != nil {
 			return diag.FromErr(fmt.Errorf("not found %s: %w", email.(string), err))
@@ -66,7 +75,7 @@ func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, m interface
 	return diags
 }
 
-func searchByName(ctx context.Context, name string, client *slack.Client) (*slack.User, error) {
+func searchByName(ctx context.Context, name string, client *apiClient) (*slack.User, error) {
 	users, err := client.GetUsersContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't get workspace users: %s", err)
@@ -48,15 +48,29 @@ func dataSourceUserGroup() *schema.Resource {
 				Set:      schema.HashString,
 				Computed: true,
 			},
+			"team_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
 		},
 	}
 }
 
 func dataSourceUserGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).userClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := client.requireScopes("data.slack_usergroup", "usergroups:read"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	teamID := m.(*providerMeta).resourceTeamID(d)
 	var group *slack.UserGroup
 
 	if name, ok := d.GetOk("name"); ok {
-		u, err := findUserGroupByName(ctx, name.(string), false, m)
+		u, err := findUserGroupByName(ctx, name.(string), false, client, teamID)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -64,7 +78,7 @@ func dataSourceUserGroupRead(ctx context.Context, d *schema.ResourceData, m inte
 	}
 
 	if id, ok := d.GetOk("usergroup_id"); ok {
-		u, err := findUserGroupByID(ctx, id.(string), false, m)
+		u, err := findUserGroupByID(ctx, id.(string), false, client, teamID)
 		if err != nil {
 			return diag.FromErr(err)
 		}
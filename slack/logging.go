@@ -0,0 +1,165 @@
+package slack
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	redactedToken = "<redacted-token>"
+	redactedEmail = "<redacted-email>"
+)
+
+var (
+	// slackTokenPattern matches the bot, user and app-level tokens, and the
+	// refresh tokens of token rotation.
+	slackTokenPattern = regexp.MustCompile(`(?:xoxe\.)?(?:xox[a-z]|xapp)-[A-Za-z0-9-]+`)
+	emailPattern      = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+	// rateLimitHeaders are the response headers logged to explain rate
+	// limits, on top of the ones starting with X-Rate-Limit.
+	rateLimitHeaders = []string{"Retry-After", "X-Slack-Req-Id"}
+)
+
+// loggingTransport is an http.RoundTripper that logs the Slack Web API calls
+// through tflog: the parameters and the outcome of each call at DEBUG level,
+// and the response bodies at TRACE level. Tokens are always redacted, and
+// email addresses when redactEmails is set.
+type loggingTransport struct {
+	next         http.RoundTripper
+	redactEmails bool
+}
+
+func newLoggingTransport(next http.RoundTripper, redactEmails bool) *loggingTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &loggingTransport{next: next, redactEmails: redactEmails}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	method := path.Base(req.URL.Path)
+
+	tflog.Debug(ctx, "slack api request", map[string]interface{}{
+		"method":      method,
+		"http_method": req.Method,
+		"params":      t.requestParams(req),
+	})
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	fields := map[string]interface{}{
+		"method":   method,
+		"duration": time.Since(start).String(),
+	}
+	if err != nil {
+		fields["error"] = t.redact(err.Error())
+		tflog.Debug(ctx, "slack api request failed", fields)
+		return nil, err
+	}
+
+	fields["status"] = resp.StatusCode
+	for name, values := range resp.Header {
+		if contains(rateLimitHeaders, name) || strings.HasPrefix(name, "X-Rate-Limit") {
+			fields[strings.ToLower(name)] = strings.Join(values, ",")
+		}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		fields["error"] = t.redact(err.Error())
+		tflog.Debug(ctx, "slack api response", fields)
+		return resp, nil
+	}
+
+	var slackResponse struct {
+		Ok       *bool  `json:"ok"`
+		Error    string `json:"error"`
+		Needed   string `json:"needed"`
+		Provided string `json:"provided"`
+		Warning  string `json:"warning"`
+	}
+	if json.Unmarshal(body, &slackResponse) == nil && slackResponse.Ok != nil {
+		fields["ok"] = *slackResponse.Ok
+		for name, value := range map[string]string{
+			"slack_error": slackResponse.Error,
+			"needed":      slackResponse.Needed,
+			"provided":    slackResponse.Provided,
+			"warning":     slackResponse.Warning,
+		} {
+			if value != "" {
+				fields[name] = value
+			}
+		}
+	}
+	tflog.Debug(ctx, "slack api response", fields)
+	tflog.Trace(ctx, "slack api response body", map[string]interface{}{
+		"method": method,
+		"body":   t.redact(string(body)),
+	})
+	return resp, nil
+}
+
+// requestParams returns the redacted query and form parameters of a request.
+// The form is read from a copy of the body, from req.GetBody, as the request
+// can't be modified; it isn't logged when the body can't be copied.
+func (t *loggingTransport) requestParams(req *http.Request) map[string]interface{} {
+	values := req.URL.Query()
+
+	var bodyErr error
+	if req.GetBody != nil && req.Body != nil && req.Body != http.NoBody &&
+		strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		var form url.Values
+		form, bodyErr = readForm(req)
+		for k, v := range form {
+			values[k] = append(values[k], v...)
+		}
+	}
+
+	params := make(map[string]interface{}, len(values)+1)
+	for k, v := range values {
+		if k == "token" {
+			params[k] = redactedToken
+			continue
+		}
+		params[k] = t.redact(strings.Join(v, ","))
+	}
+	if bodyErr != nil {
+		params["body_error"] = t.redact(bodyErr.Error())
+	}
+	return params
+}
+
+func readForm(req *http.Request) (url.Values, error) {
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	return url.ParseQuery(string(data))
+}
+
+// redact removes the tokens, and the email addresses if configured, from s.
+func (t *loggingTransport) redact(s string) string {
+	s = slackTokenPattern.ReplaceAllString(s, redactedToken)
+	if !t.redactEmails {
+		return s
+	}
+	return emailPattern.ReplaceAllString(s, redactedEmail)
+}
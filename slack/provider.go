@@ -2,9 +2,12 @@ package slack
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/slack-go/slack"
 )
 
@@ -14,15 +17,101 @@ func Provider() *schema.Provider {
 		Schema: map[string]*schema.Schema{
 			"token": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("SLACK_TOKEN", nil),
-				Description: "The Slack token",
+				Description: "The Slack token, used for the calls that don't have a more specific token configured",
 			},
+			"bot_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("SLACK_BOT_TOKEN", nil),
+				Description: "The Slack bot token, used to manage channels and their members",
+			},
+			"user_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("SLACK_USER_TOKEN", nil),
+				Description: "The Slack user token, used to manage usergroups",
+			},
+			"admin_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("SLACK_ADMIN_TOKEN", nil),
+				Description: "The Slack user token of an org admin, used for the admin.* APIs",
+			},
+			"team_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SLACK_TEAM_ID", nil),
+				Description: "The workspace of an Enterprise Grid org the resources are managed in by default, needed with org-level tokens",
+			},
+			"api_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SLACK_API_URL", slack.APIURL),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "The base URL of the Slack Web API",
+			},
+			"request_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDuration,
+				Description:  "The maximum time a Slack API call may take, including retries, e.g. 2m",
+			},
+			"http_proxy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+				Description:  "The URL of the proxy used to reach the Slack API. Defaults to the HTTP_PROXY and HTTPS_PROXY environment variables",
+			},
+			"ca_bundle_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path of a PEM file with additional CA certificates to trust when connecting to the Slack API",
+			},
+			"skip_credentials_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Skip the validation of the token and of its scopes with auth.test when the provider is configured",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultMaxRetries,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of times a Slack API call is retried when rate limited or on transient errors",
+			},
+			"max_backoff": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultMaxBackoff.String(),
+				ValidateFunc: validateDuration,
+				Description:  "The maximum time to wait between retries of a Slack API call, e.g. 30s",
+			},
+			"log_redact_emails": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Redact the email addresses of users from the Slack API calls logged with TF_LOG",
+			},
+			"conversation_naming": conversationNamingSchema(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"slack_conversation": resourceSlackConversation(),
-			"slack_usergroup":    resourceSlackUserGroup(),
+			"slack_conversation":                resourceSlackConversation(),
+			"slack_conversation_bookmark":       resourceSlackConversationBookmark(),
+			"slack_conversation_connect_invite": resourceSlackConversationConnectInvite(),
+			"slack_conversation_idp_group":      resourceSlackConversationIDPGroup(),
+			"slack_conversation_member":         resourceSlackConversationMember(),
+			"slack_conversation_members":        resourceSlackConversationMembers(),
+			"slack_conversation_retention":      resourceSlackConversationRetention(),
+			"slack_pinned_message":              resourceSlackPinnedMessage(),
+			"slack_usergroup":                   resourceSlackUserGroup(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -35,15 +124,50 @@ func Provider() *schema.Provider {
 	}
 }
 
-func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
-	token, ok := d.GetOk("token")
-	if !ok {
+	config, err := newConfig(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	naming, err := newConversationNaming(d.Get("conversation_naming").([]interface{}))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	meta := &providerMeta{
+		clients:            map[string]*apiClient{},
+		teamID:             d.Get("team_id").(string),
+		conversationNaming: naming,
+	}
+	for name, token := range config.tokens {
+		client, err := newAPIClient(config, token)
+		if err != nil {
+			return nil, diag.Errorf("could not create slack client: %s", err)
+		}
+		client.name = name
+
+		if !d.Get("skip_credentials_validation").(bool) {
+			if _, err := client.authenticate(ctx); err != nil {
+				return nil, diag.Errorf("could not validate the Slack %s: %s", name, err)
+			}
+		}
+		meta.clients[name] = client
+	}
+
+	if len(meta.clients) == 0 {
 		return nil, diag.Errorf("could not create slack client. Please provide a token.")
 	}
-	slackClient := slack.New(token.(string))
-	return slackClient, diags
+	return meta, diags
+}
+
+func validateDuration(v interface{}, k string) ([]string, []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s is not a valid duration: %s", k, err)}
+	}
+	return nil, nil
 }
 
 func schemaSetToSlice(set *schema.Set) []string {
@@ -60,6 +60,14 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestProviderTokensAreSensitive(t *testing.T) {
+	for _, name := range []string{"token", "bot_token", "user_token", "admin_token"} {
+		if !Provider().Schema[name].Sensitive {
+			t.Errorf("%s isn't sensitive", name)
+		}
+	}
+}
+
 func TestProvider_impl(_ *testing.T) {
 	var _ *schema.Provider = Provider()
 }
@@ -68,4 +76,15 @@ func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("SLACK_TOKEN"); v == "" {
 		t.Fatal("SLACK_TOKEN must be set for acceptance tests")
 	}
+	testAccCassette(t)
+}
+
+// testAccPreCheckAdmin skips the tests of the admin APIs when they run
+// against Slack without an admin token. The fake and the cassettes accept any
+// token.
+func testAccPreCheckAdmin(t *testing.T) {
+	testAccPreCheck(t)
+	if os.Getenv("SLACK_ADMIN_TOKEN") == "" && os.Getenv("SLACK_TOKEN") != fakeSlackToken && testCassettes == nil {
+		t.Skip("SLACK_ADMIN_TOKEN must be set for acceptance tests of the admin APIs")
+	}
 }
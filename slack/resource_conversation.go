@@ -3,18 +3,23 @@ package slack
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/slack-go/slack"
 )
 
 const (
-	conversationActionOnDestroyNone    = "none"
-	conversationActionOnDestroyArchive = "archive"
+	conversationActionOnDestroyNone          = "none"
+	conversationActionOnDestroyArchive       = "archive"
+	conversationActionOnDestroyRenameArchive = "rename_archive"
+	conversationActionOnDestroyDelete        = "delete"
 
 	conversationActionOnUpdatePermanentMembersNone = "none"
 	conversationActionOnUpdatePermanentMembersKick = "kick"
@@ -22,12 +27,21 @@ const (
 	// 100 is default, slack docs recommend no more than 200, but 1000 is the max.
 	// See also https://github.com/slack-go/slack/blob/master/users.go#L305
 	cursorLimit = 200
+
+	conversationImportNamePrefix    = "name:"
+	conversationImportPrivatePrefix = "private:"
+
+	// maxInvitesPerCall is the maximum number of users conversations.invite
+	// accepts at once.
+	maxInvitesPerCall = 1000
 )
 
 var (
 	conversationActionValidValues = []string{
 		conversationActionOnDestroyNone,
 		conversationActionOnDestroyArchive,
+		conversationActionOnDestroyRenameArchive,
+		conversationActionOnDestroyDelete,
 	}
 	conversationActionOnUpdatePermanentMembersValidValues = []string{
 		conversationActionOnUpdatePermanentMembersNone,
@@ -44,9 +58,21 @@ func resourceSlackConversation() *schema.Resource {
 		CreateContext: resourceSlackConversationCreate,
 		UpdateContext: resourceSlackConversationUpdate,
 		DeleteContext: resourceSlackConversationDelete,
+		Timeouts:      conversationTimeouts(),
+		CustomizeDiff: customdiff.All(
+			customizeDiffConversationScopes,
+			customizeDiffConversationName,
+			customizeDiffConversationVisibility,
+			customizeDiffConversationTeam,
+			customizeDiffConversationTeams,
+			customizeDiffPostingPermissions,
+			customdiff.ComputedIf("members", func(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
+				return d.HasChanges("permanent_members", "permanent_usergroups")
+			}),
+		),
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceSlackConversationImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -71,6 +97,21 @@ func resourceSlackConversation() *schema.Resource {
 				Set:      schema.HashString,
 				Optional: true,
 			},
+			"permanent_usergroups": {
+				Type:        schema.TypeSet,
+				Description: "The usergroups whose users are added to the conversation, on top of the permanent members",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+			},
+			"members": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:      schema.HashString,
+				Computed: true,
+			},
 			"created": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -80,8 +121,9 @@ func resourceSlackConversation() *schema.Resource {
 				Computed: true,
 			},
 			"is_private": {
-				Type:     schema.TypeBool,
-				Required: true,
+				Type:        schema.TypeBool,
+				Description: "Changing it converts the conversation in place when the token of the admin APIs has the admin.conversations:write scope, and recreates it otherwise",
+				Required:    true,
 			},
 			"is_archived": {
 				Type:     schema.TypeBool,
@@ -106,7 +148,7 @@ func resourceSlackConversation() *schema.Resource {
 			},
 			"action_on_destroy": {
 				Type:         schema.TypeString,
-				Description:  "Either of none or archive",
+				Description:  "Either of none, archive, rename_archive or delete",
 				Optional:     true,
 				Default:      "archive",
 				ValidateFunc: validateConversationActionOnDestroyValue,
@@ -118,27 +160,142 @@ func resourceSlackConversation() *schema.Resource {
 				Default:      "kick",
 				ValidateFunc: validateConversationActionOnUpdatePermanentMembers,
 			},
+			"posting_permissions": postingPermissionsSchema(),
+			"ignored_members": {
+				Type:        schema.TypeSet,
+				Description: "Members that are never kicked, and whose membership doesn't show up as drift",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+			},
+			"ignore_bots": {
+				Type:        schema.TypeBool,
+				Description: "Never kick bots, and don't show their membership as drift",
+				Optional:    true,
+				Default:     false,
+			},
+			"ignore_external_members": {
+				Type:        schema.TypeBool,
+				Description: "Never kick the members of other organizations, e.g. of Slack Connect channels, and don't show their membership as drift",
+				Optional:    true,
+				Default:     false,
+			},
 			"adopt_existing_channel": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  false,
 			},
+			"team_id": {
+				Type:        schema.TypeString,
+				Description: "The workspace of an Enterprise Grid org to create the conversation in. Changing it moves the conversation in place when the token of the admin APIs has the admin.conversations:write scope, and recreates it otherwise",
+				Optional:    true,
+				Computed:    true,
+				// conversations.info doesn't return the workspace of a
+				// conversation, so it is unknown for imported ones.
+				DiffSuppressFunc: func(_, old, _ string, d *schema.ResourceData) bool {
+					return old == "" && d.Id() != ""
+				},
+			},
+			"team_ids": {
+				Type:        schema.TypeSet,
+				Description: "The workspaces of an Enterprise Grid org the conversation is shared with, including its own. Managed with the admin_token of the provider",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+			},
 		},
 	}
 }
 
+// customizeDiffConversationScopes checks the scopes of the bot token, and
+// those of the user token when the conversation has permanent usergroups. The
+// ignored bots and external members, and the users of the usergroups, are
+// told apart with users.info.
+func customizeDiffConversationScopes(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return err
+	}
+	scopes := []string{"channels:read", "channels:manage"}
+	if d.Get("is_private").(bool) {
+		scopes = []string{"groups:read", "groups:write"}
+	}
+	usergroups := !d.NewValueKnown("permanent_usergroups") || d.Get("permanent_usergroups").(*schema.Set).Len() > 0
+	if usergroups || d.Get("ignore_bots").(bool) || d.Get("ignore_external_members").(bool) {
+		scopes = append(scopes, "users:read")
+	}
+	if err := client.requireScopes("slack_conversation", scopes...); err != nil {
+		return err
+	}
+	if !usergroups {
+		return nil
+	}
+
+	userClient, err := m.(*providerMeta).userClient()
+	if err != nil {
+		return err
+	}
+	return userClient.requireScopes("slack_conversation", "usergroups:read")
+}
+
+// customizeDiffConversationVisibility plans the replacement of conversations
+// that are made public or private, unless they can be converted in place with
+// the admin APIs. The new channel takes the name of the old one, so the
+// replacement is only planned when the old channel is renamed on destroy.
+func customizeDiffConversationVisibility(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("is_private") {
+		return nil
+	}
+	if m.(*providerMeta).hasAdminAPIs() {
+		tflog.Info(ctx, "conversation will be converted in place", map[string]interface{}{
+			"channel":    d.Id(),
+			"is_private": d.Get("is_private"),
+		})
+		return nil
+	}
+	if action, _ := d.GetChange("action_on_destroy"); action != conversationActionOnDestroyRenameArchive {
+		return fmt.Errorf("is_private of conversation %s can't be changed in place without the admin.conversations:write scope, "+
+			"and replacing it needs action_on_destroy to be %s so that its name can be reused: apply action_on_destroy = %q first",
+			d.Id(), conversationActionOnDestroyRenameArchive, conversationActionOnDestroyRenameArchive)
+	}
+	return d.ForceNew("is_private")
+}
+
+// convertConversation makes a conversation private or public with the admin
+// APIs.
+func convertConversation(ctx context.Context, m *providerMeta, channelID string, isPrivate bool) error {
+	client, err := m.adminClient()
+	if err != nil {
+		return err
+	}
+
+	method := "admin.conversations.convertToPublic"
+	if isPrivate {
+		method = "admin.conversations.convertToPrivate"
+	}
+	if _, err := client.call(ctx, method, url.Values{"channel_id": {channelID}}, nil); err != nil {
+		return fmt.Errorf("couldn't convert conversation %s with %s: %w", channelID, method, err)
+	}
+	return nil
+}
+
 func resourceSlackConversationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*slack.Client)
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	name := d.Get("name").(string)
 	isPrivate := d.Get("is_private").(bool)
+	teamID := m.(*providerMeta).resourceTeamID(d)
 
 	channel, err := client.CreateConversationContext(ctx, slack.CreateConversationParams{
 		ChannelName: name,
 		IsPrivate:   isPrivate,
+		TeamID:      teamID,
 	})
 	if err != nil && err.Error() == "name_taken" && d.Get("adopt_existing_channel").(bool) {
-		channel, err = findExistingChannel(ctx, client, name, isPrivate)
+		channel, err = findExistingChannel(ctx, client, name, isPrivate, teamID)
 		if err == nil && channel.IsArchived {
 			// ensure unarchived first if adopting existing channel, else other calls below will fail
 			if err := client.UnArchiveConversationContext(ctx, channel.ID); err != nil {
@@ -152,7 +309,7 @@ func resourceSlackConversationCreate(ctx context.Context, d *schema.ResourceData
 		return diag.Errorf("could not create conversation %s: %s", name, err)
 	}
 
-	err = updateChannelMembers(ctx, d, client, channel.ID)
+	err = updateChannelMembers(ctx, d, m.(*providerMeta), client, channel.ID)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -169,6 +326,22 @@ func resourceSlackConversationCreate(ctx context.Context, d *schema.ResourceData
 		}
 	}
 
+	if postingPermissions, ok := d.GetOk("posting_permissions"); ok {
+		prefs, err := expandPostingPermissions(postingPermissions.([]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := setConversationPrefs(ctx, m.(*providerMeta), channel.ID, prefs); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if teamIDs, ok := d.GetOk("team_ids"); ok {
+		if err := setConversationTeams(ctx, m.(*providerMeta), channel.ID, teamID, schemaSetToSlice(teamIDs.(*schema.Set))); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if isArchived, ok := d.GetOk("is_archived"); ok {
 		if isArchived.(bool) {
 			err := archiveConversationWithContext(ctx, client, channel.ID)
@@ -179,10 +352,51 @@ func resourceSlackConversationCreate(ctx context.Context, d *schema.ResourceData
 	}
 
 	d.SetId(channel.ID)
+	if teamID != "" {
+		if err := d.Set("team_id", teamID); err != nil {
+			return diag.Errorf("error setting team_id: %s", err)
+		}
+	}
 	return resourceSlackConversationRead(ctx, d, m)
 }
 
-func findExistingChannel(ctx context.Context, client *slack.Client, name string, isPrivate bool) (*slack.Channel, error) {
+// resourceSlackConversationImport accepts the ID of the conversation, or its
+// name as name:<name> for public channels and private:name:<name> for private
+// ones. The arguments that only drive the provider get their defaults, so that
+// the plan after the import is empty.
+func resourceSlackConversationImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+	isPrivate := strings.HasPrefix(id, conversationImportPrivatePrefix)
+	if name := strings.TrimPrefix(id, conversationImportPrivatePrefix); strings.HasPrefix(name, conversationImportNamePrefix) {
+		name = strings.TrimPrefix(name, conversationImportNamePrefix)
+		client, err := m.(*providerMeta).botClient()
+		if err != nil {
+			return nil, err
+		}
+		channel, err := findExistingChannel(ctx, client, name, isPrivate, m.(*providerMeta).teamID)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't import conversation %s: %w", id, err)
+		}
+		d.SetId(channel.ID)
+	} else if isPrivate {
+		return nil, fmt.Errorf("invalid import ID %q, expected <channel_id>, name:<name> or private:name:<name>", id)
+	}
+
+	for key, value := range map[string]interface{}{
+		"action_on_destroy":                  conversationActionOnDestroyArchive,
+		"action_on_update_permanent_members": conversationActionOnUpdatePermanentMembersKick,
+		"adopt_existing_channel":             false,
+		"ignore_bots":                        false,
+		"ignore_external_members":            false,
+	} {
+		if err := d.Set(key, value); err != nil {
+			return nil, fmt.Errorf("error setting %s: %w", key, err)
+		}
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func findExistingChannel(ctx context.Context, client *apiClient, name string, isPrivate bool, teamID string) (*slack.Channel, error) {
 	// find the existing channel. Sadly, there is no non-admin API to search by name,
 	// so we must search through ALL the channels
 	tflog.Info(ctx, "Looking for channel %s", map[string]interface{}{"channel": name})
@@ -192,12 +406,14 @@ func findExistingChannel(ctx context.Context, client *slack.Client, name string,
 	if isPrivate {
 		types = append(types, "private_channel")
 	}
+	checked := 0
 	for !paginationComplete {
 		channels, nextCursor, err := client.GetConversationsContext(ctx, &slack.GetConversationsParameters{
 			Cursor:          cursor,
 			Limit:           cursorLimit,
 			Types:           types,
 			ExcludeArchived: true,
+			TeamID:          teamID,
 		})
 		tflog.Debug(ctx, "new page of channels",
 			map[string]interface{}{
@@ -205,40 +421,63 @@ func findExistingChannel(ctx context.Context, client *slack.Client, name string,
 				"nextCursor":  nextCursor,
 				"err":         err})
 		if err != nil {
-			if rateLimitedError, ok := err.(*slack.RateLimitedError); ok {
-				tflog.Warn(ctx, "rate limited", map[string]interface{}{"seconds": rateLimitedError.RetryAfter.Seconds()})
-				select {
-				case <-ctx.Done():
-					return nil, fmt.Errorf("canceled during pagination: %s", ctx.Err())
-				case <-time.After(rateLimitedError.RetryAfter):
-					tflog.Debug(ctx, "done sleeping after rate limited")
-				}
-				// retry current cursor
-			} else {
-				return nil, fmt.Errorf("couldn't get conversation context: %s", err.Error())
-			}
-		} else {
-			// see if channel in current batch
-			for _, c := range channels {
-				tflog.Trace(ctx, "checking channel", map[string]interface{}{"channel": c.Name})
-				if c.Name == name {
-					tflog.Info(ctx, "found channel")
-					return &c, nil
-				}
+			// rate limits and transient errors are already retried by the client
+			return nil, withProgress(ctx, fmt.Errorf("couldn't get conversation context: %w", err),
+				"looking through %d channels for %s", checked, name)
+		}
+		// see if channel in current batch
+		for _, c := range channels {
+			tflog.Trace(ctx, "checking channel", map[string]interface{}{"channel": c.Name})
+			if c.Name == name {
+				tflog.Info(ctx, "found channel")
+				return &c, nil
 			}
-			// not found so far, move on to next cursor, if pagination incomplete
-			paginationComplete = nextCursor == ""
-			cursor = nextCursor
 		}
+		checked += len(channels)
+		// not found so far, move on to next cursor, if pagination incomplete
+		paginationComplete = nextCursor == ""
+		cursor = nextCursor
 	}
 	// looked through entire list, but didn't find matching name
 	return nil, fmt.Errorf("could not find channel with name %s", name)
 }
 
-func updateChannelMembers(ctx context.Context, d *schema.ResourceData, client *slack.Client, channelID string) error {
-	members := d.Get("permanent_members").(*schema.Set)
+// updateChannelMembers makes the permanent members and the users of the
+// permanent usergroups members of the conversation.
+func updateChannelMembers(ctx context.Context, d *schema.ResourceData, m *providerMeta, client *apiClient, channelID string) error {
+	usergroupUsers, missing, err := expandPermanentUsergroups(ctx, d, m)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("couldn't expand permanent_usergroups: could not find usergroups %s, they may be disabled or deleted", strings.Join(missing, ", "))
+	}
+	userIDs := schemaSetToSlice(d.Get("permanent_members").(*schema.Set))
+	if usergroupUserIDs := usergroupMembers(usergroupUsers); len(usergroupUserIDs) > 0 {
+		members, err := getConversationMembers(ctx, client, channelID)
+		if err != nil {
+			return fmt.Errorf("could not retrieve conversation users for ID %s: %w", channelID, err)
+		}
+		uninvitable, err := uninvitableUsers(ctx, client, m.resourceTeamID(d), usergroupUserIDs, members)
+		if err != nil {
+			return err
+		}
+		for _, userID := range usergroupUserIDs {
+			if !uninvitable[userID] && !contains(userIDs, userID) {
+				userIDs = append(userIDs, userID)
+			}
+		}
+	}
+
+	kick := d.Get("action_on_update_permanent_members").(string) == conversationActionOnUpdatePermanentMembersKick
+	return setConversationMembers(ctx, client, channelID, userIDs, kick, expandIgnoredMembers(d))
+}
 
-	userIds := schemaSetToSlice(members)
+// setConversationMembers invites the users who aren't members of the
+// conversation yet and, if kick is set, kicks the members who aren't in
+// userIds. The creator of the conversation, the API user and the ignored
+// members are never kicked.
+func setConversationMembers(ctx context.Context, client *apiClient, channelID string, userIds []string, kick bool, ignored ignoredMembers) error {
 	channel, err := client.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
 		ChannelID: channelID,
 	})
@@ -246,18 +485,14 @@ func updateChannelMembers(ctx context.Context, d *schema.ResourceData, client *s
 		return fmt.Errorf("could not retrieve conversation info for ID %s: %w", channelID, err)
 	}
 
-	apiUserInfo, err := client.AuthTest()
-
+	apiUserInfo, err := client.identity(ctx)
 	if err != nil {
-		return fmt.Errorf("error authenticating with slack %w", err)
+		return err
 	}
-	userIds = remove(userIds, apiUserInfo.UserID)
+	userIds = remove(userIds, apiUserInfo.userID)
 	userIds = remove(userIds, channel.Creator)
 
-	channelUsers, _, err := client.GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
-		ChannelID: channel.ID,
-	})
-
+	channelUsers, err := getConversationMembers(ctx, client, channel.ID)
 	if err != nil {
 		return fmt.Errorf("could not retrieve conversation users for ID %s: %w", channelID, err)
 	}
@@ -269,30 +504,95 @@ func updateChannelMembers(ctx context.Context, d *schema.ResourceData, client *s
 		}
 	}
 
-	action := d.Get("action_on_update_permanent_members").(string)
-	if action == conversationActionOnUpdatePermanentMembersKick {
+	// channels may have thousands of members, so look them up in sets
+	permanentMembers := make(map[string]bool, len(userIds))
+	for _, userID := range userIds {
+		permanentMembers[userID] = true
+	}
+	currentMembers := make(map[string]bool, len(channelUsers))
+	for _, userID := range channelUsers {
+		currentMembers[userID] = true
+	}
+
+	if kick {
+		var candidates []string
 		for _, currentMember := range channelUsers {
-			if currentMember != channel.Creator && currentMember != apiUserInfo.UserID && !contains(userIds, currentMember) {
-				if err := client.KickUserFromConversationContext(ctx, channelID, currentMember); err != nil {
-					return fmt.Errorf("couldn't kick user from conversation: %w", err)
-				}
+			if currentMember != channel.Creator && currentMember != apiUserInfo.userID && !permanentMembers[currentMember] {
+				candidates = append(candidates, currentMember)
+			}
+		}
+		ignoredUsers, err := ignored.ignored(ctx, client, candidates)
+		if err != nil {
+			return err
+		}
+		var kicks []string
+		for _, userID := range candidates {
+			if !ignoredUsers[userID] {
+				kicks = append(kicks, userID)
+			}
+		}
+		for i, userID := range kicks {
+			if err := client.KickUserFromConversationContext(ctx, channelID, userID); err != nil {
+				return withProgress(ctx, fmt.Errorf("couldn't kick user from conversation: %w", err),
+					"kicking %d of %d members from conversation %s", i, len(kicks), channelID)
 			}
 		}
 	}
 
-	if len(userIds) > 0 {
-		if _, err := client.InviteUsersToConversationContext(ctx, channelID, userIds...); err != nil {
+	var invites []string
+	for _, userID := range userIds {
+		if !currentMembers[userID] {
+			invites = append(invites, userID)
+		}
+	}
+	return inviteUsersToConversation(ctx, client, channelID, invites)
+}
+
+// getConversationMembers returns all the members of a conversation, going
+// through every page of conversations.members.
+func getConversationMembers(ctx context.Context, client *apiClient, channelID string) ([]string, error) {
+	var members []string
+	cursor := ""
+	for {
+		users, nextCursor, err := client.GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
+			ChannelID: channelID,
+			Cursor:    cursor,
+			Limit:     cursorLimit,
+		})
+		if err != nil {
+			return nil, withProgress(ctx, err, "reading %d members of conversation %s", len(members), channelID)
+		}
+		members = append(members, users...)
+		if nextCursor == "" {
+			return members, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+// inviteUsersToConversation invites the users in batches, as Slack limits the
+// number of users invited in one call.
+func inviteUsersToConversation(ctx context.Context, client *apiClient, channelID string, userIDs []string) error {
+	for start := 0; start < len(userIDs); start += maxInvitesPerCall {
+		end := start + maxInvitesPerCall
+		if end > len(userIDs) {
+			end = len(userIDs)
+		}
+		if _, err := client.InviteUsersToConversationContext(ctx, channelID, userIDs[start:end]...); err != nil {
 			if err.Error() != "already_in_channel" {
-				return fmt.Errorf("couldn't invite users to conversation: %w", err)
+				return withProgress(ctx, fmt.Errorf("couldn't invite users to conversation: %w", err),
+					"inviting %d of %d users to conversation %s", start, len(userIDs), channelID)
 			}
 		}
 	}
-
 	return nil
 }
 
 func resourceSlackConversationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*slack.Client)
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	id := d.Id()
 	var diags diag.Diagnostics
 	channel, err := client.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
@@ -310,20 +610,176 @@ func resourceSlackConversationRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(fmt.Errorf("couldn't get conversation info for %s: %w", id, err))
 	}
 
-	users, _, err := client.GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
-		ChannelID: channel.ID,
-	})
+	users, err := getConversationMembers(ctx, client, channel.ID)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("couldn't get users in conversation for %s: %w", channel.ID, err))
 	}
-	return updateChannelData(d, channel, users)
+	if diags := updateChannelData(d, channel, users); diags.HasError() {
+		return diags
+	}
+
+	// checked before permanent_usergroups is set to the actual usergroups
+	managed := managesPermanentMembers(d)
+
+	// The usergroups are expanded on every read, so that the users added to
+	// them since the last apply show up as drift.
+	// Usergroups disabled or deleted outside of Terraform show up as drift.
+	usergroupUsers, missing, err := expandPermanentUsergroups(ctx, d, m.(*providerMeta))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for _, usergroupID := range missing {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("usergroup %s of conversation %s not found, removing it from permanent_usergroups", usergroupID, id),
+			Detail:   "The usergroup may have been disabled or deleted.",
+		})
+	}
+	uninvitable, err := uninvitableUsers(ctx, client, m.(*providerMeta).resourceTeamID(d), usergroupMembers(usergroupUsers), users)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("permanent_usergroups", actualPermanentUsergroups(usergroupUsers, users, uninvitable)); err != nil {
+		return diag.Errorf("error setting permanent_usergroups: %s", err)
+	}
+
+	// Members that are kicked on update are expected to match the permanent
+	// members, so anyone who joined or left by hand shows up as drift. It's
+	// only reported when the members are managed, so that the channels without
+	// permanent members aren't planned to be emptied.
+	if managed && d.Get("action_on_update_permanent_members").(string) == conversationActionOnUpdatePermanentMembersKick {
+		apiUserInfo, err := client.identity(ctx)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		previous := schemaSetToSlice(d.Get("permanent_members").(*schema.Set))
+		neverKicked := append([]string{channel.Creator, apiUserInfo.userID}, usergroupMembers(usergroupUsers)...)
+		// only the members that would be kicked need to be looked up
+		kept := make(map[string]bool, len(previous)+len(neverKicked))
+		for _, userID := range previous {
+			kept[userID] = true
+		}
+		for _, userID := range neverKicked {
+			kept[userID] = true
+		}
+		var candidates []string
+		for _, userID := range users {
+			if !kept[userID] {
+				candidates = append(candidates, userID)
+			}
+		}
+		ignored, err := expandIgnoredMembers(d).ignored(ctx, client, candidates)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for userID := range ignored {
+			neverKicked = append(neverKicked, userID)
+		}
+		permanentMembers := actualPermanentMembers(previous, users, neverKicked...)
+		if err := d.Set("permanent_members", permanentMembers); err != nil {
+			return diag.Errorf("error setting permanent_members: %s", err)
+		}
+	}
+
+	// the prefs are only read when managed, as reading them needs an admin
+	if len(d.Get("posting_permissions").([]interface{})) > 0 {
+		prefs, err := getConversationPrefs(ctx, m.(*providerMeta), channel.ID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("posting_permissions", flattenPostingPermissions(prefs)); err != nil {
+			return diag.Errorf("error setting posting_permissions: %s", err)
+		}
+	}
+
+	// the workspaces are only read when managed, as reading them needs an admin
+	if d.Get("team_ids").(*schema.Set).Len() > 0 {
+		teamIDs, err := getConversationTeams(ctx, m.(*providerMeta), channel.ID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("team_ids", teamIDs); err != nil {
+			return diag.Errorf("error setting team_ids: %s", err)
+		}
+	}
+	return diags
+}
+
+// managesPermanentMembers reports whether the members of a conversation are
+// managed with permanent_members or permanent_usergroups, i.e. whether either
+// is set in its state, or in its config when it's read after an apply.
+func managesPermanentMembers(d *schema.ResourceData) bool {
+	return d.Get("permanent_members").(*schema.Set).Len() > 0 || d.Get("permanent_usergroups").(*schema.Set).Len() > 0
+}
+
+// actualPermanentMembers returns the permanent members matching the members
+// of a channel. The users that are never kicked, e.g. the creator and the API
+// user, are only kept if they were already permanent members.
+func actualPermanentMembers(previous, members []string, neverKicked ...string) []string {
+	ignored := make(map[string]bool, len(neverKicked))
+	for _, userID := range neverKicked {
+		ignored[userID] = true
+	}
+	for _, userID := range previous {
+		delete(ignored, userID)
+	}
+
+	permanentMembers := []string{}
+	for _, member := range members {
+		if ignored[member] {
+			continue
+		}
+		permanentMembers = append(permanentMembers, member)
+	}
+	return permanentMembers
 }
 
 func resourceSlackConversationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*slack.Client)
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	id := d.Id()
 
+	if d.HasChange("is_private") {
+		if err := convertConversation(ctx, m.(*providerMeta), id, d.Get("is_private").(bool)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	var diags diag.Diagnostics
+	teamID := m.(*providerMeta).resourceTeamID(d)
+	moved := d.HasChange("team_id")
+	if moved {
+		if err := moveConversation(ctx, m.(*providerMeta), id, teamID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// Slack moves conversations in the background, so the workspaces of a
+	// moved conversation are left to the next apply, which sees the actual
+	// ones as drift. Removing team_ids shares the conversation with its own
+	// workspace only.
+	if d.HasChange("team_ids") && moved {
+		old, _ := d.GetChange("team_ids")
+		if err := d.Set("team_ids", old); err != nil {
+			return diag.Errorf("error setting team_ids: %s", err)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("conversation %s is being moved to %s, team_ids will be set by the next apply", id, teamID),
+		})
+	} else if d.HasChange("team_ids") {
+		teamIDs := schemaSetToSlice(d.Get("team_ids").(*schema.Set))
+		if len(teamIDs) == 0 {
+			teamIDs = []string{teamID}
+		}
+		if err := setConversationTeams(ctx, m.(*providerMeta), id, teamID, teamIDs); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if d.HasChange("name") {
 		if _, err := client.RenameConversationContext(ctx, id, d.Get("name").(string)); err != nil {
 			return diag.Errorf("couldn't rename conversation: %s", err)
@@ -360,19 +816,33 @@ func resourceSlackConversationUpdate(ctx context.Context, d *schema.ResourceData
 		}
 	}
 
-	if d.HasChange("permanent_members") {
-		err := updateChannelMembers(ctx, d, client, id)
+	if d.HasChanges("permanent_members", "permanent_usergroups") {
+		err := updateChannelMembers(ctx, d, m.(*providerMeta), client, id)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 	}
 
-	return resourceSlackConversationRead(ctx, d, m)
+	// removing posting_permissions lets everyone post and reply again
+	if d.HasChange("posting_permissions") {
+		prefs, err := expandPostingPermissions(d.Get("posting_permissions").([]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := setConversationPrefs(ctx, m.(*providerMeta), id, prefs); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return append(diags, resourceSlackConversationRead(ctx, d, m)...)
 }
 
 func resourceSlackConversationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*slack.Client)
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	id := d.Id()
 	action := d.Get("action_on_destroy").(string)
@@ -391,6 +861,40 @@ func resourceSlackConversationDelete(ctx context.Context, d *schema.ResourceData
 			}
 			return diag.FromErr(err)
 		}
+	case conversationActionOnDestroyRenameArchive:
+		name := archivedConversationName(d.Get("name").(string), time.Now())
+		if _, err := client.RenameConversationContext(ctx, id, name); err != nil {
+			switch err.Error() {
+			case "channel_not_found":
+				return diags
+			case "is_archived":
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("conversation %s (%s) is already archived and can't be renamed", id, d.Get("name")),
+					Detail:   "The name of the conversation stays taken.",
+				})
+				return diags
+			}
+			return diag.Errorf("couldn't rename conversation %s to %s: %s", id, name, err)
+		}
+		if err := archiveConversationWithContext(ctx, client, id); err != nil {
+			return diag.FromErr(err)
+		}
+	case conversationActionOnDestroyDelete:
+		if !m.(*providerMeta).hasAdminAPIs() {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("conversation %s (%s) is archived instead of deleted", id, d.Get("name")),
+				Detail:   fmt.Sprintf("action_on_destroy is set to %s, which needs the admin.conversations:write scope in the admin_token of the provider, or in token when it is not configured", conversationActionOnDestroyDelete),
+			})
+			if err := archiveConversationWithContext(ctx, client, id); err != nil {
+				return append(diags, diag.FromErr(err)...)
+			}
+			return diags
+		}
+		if err := deleteConversation(ctx, m.(*providerMeta), id); err != nil {
+			return diag.FromErr(err)
+		}
 	default:
 		return diag.Errorf("unknown action_on_destroy value. Valid values are %v", conversationActionValidValues)
 	}
@@ -398,7 +902,7 @@ func resourceSlackConversationDelete(ctx context.Context, d *schema.ResourceData
 	return diags
 }
 
-func updateChannelData(d *schema.ResourceData, channel *slack.Channel, _ []string) diag.Diagnostics {
+func updateChannelData(d *schema.ResourceData, channel *slack.Channel, users []string) diag.Diagnostics {
 	if channel.ID == "" {
 		return diag.Errorf("error setting id: returned channel does not have an id")
 	}
@@ -454,10 +958,44 @@ func updateChannelData(d *schema.ResourceData, channel *slack.Channel, _ []strin
 		return diag.Errorf("error setting is_general: %s", err)
 	}
 
+	if err := d.Set("members", users); err != nil {
+		return diag.Errorf("error setting members: %s", err)
+	}
+
+	return nil
+}
+
+// archivedConversationName returns the name a conversation is renamed to
+// before being archived, so that its name can be used again. The timestamp
+// has milliseconds, as periods aren't allowed, so that a name destroyed twice
+// in a second gets two names. The name is shortened to keep within the
+// maximum length of conversation names.
+func archivedConversationName(name string, now time.Time) string {
+	suffix := "-" + strings.Replace(now.UTC().Format("20060102150405.000"), ".", "", 1)
+	// names are shortened on a character boundary, as they can be non-ASCII
+	if runes := []rune(name); len(runes)+len(suffix) > conversationNameMaxLength {
+		name = string(runes[:conversationNameMaxLength-len(suffix)])
+	}
+	return name + suffix
+}
+
+// deleteConversation deletes a conversation with the admin APIs. It is not an
+// error if the conversation was already deleted.
+func deleteConversation(ctx context.Context, m *providerMeta, channelID string) error {
+	client, err := m.adminClient()
+	if err != nil {
+		return err
+	}
+	if _, err := client.call(ctx, "admin.conversations.delete", url.Values{"channel_id": {channelID}}, nil); err != nil {
+		if err.Error() == "channel_not_found" {
+			return nil
+		}
+		return fmt.Errorf("couldn't delete conversation %s: %w", channelID, err)
+	}
 	return nil
 }
 
-func archiveConversationWithContext(ctx context.Context, client *slack.Client, id string) error {
+func archiveConversationWithContext(ctx context.Context, client *apiClient, id string) error {
 	if err := client.ArchiveConversationContext(ctx, id); err != nil {
 		if err.Error() != "already_archived" {
 			return fmt.Errorf("couldn't archive conversation %s: %s", id, err)
@@ -0,0 +1,181 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/slack-go/slack"
+)
+
+func resourceSlackConversationBookmark() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceSlackConversationBookmarkRead,
+		CreateContext: resourceSlackConversationBookmarkCreate,
+		UpdateContext: resourceSlackConversationBookmarkUpdate,
+		DeleteContext: resourceSlackConversationBookmarkDelete,
+		CustomizeDiff: func(_ context.Context, _ *schema.ResourceDiff, m interface{}) error {
+			client, err := m.(*providerMeta).botClient()
+			if err != nil {
+				return err
+			}
+			return client.requireScopes("slack_conversation_bookmark", "bookmarks:read", "bookmarks:write")
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"channel_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"title": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"link": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"emoji": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The emoji of the bookmark, e.g. :books:",
+			},
+			"bookmark_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSlackConversationBookmarkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	channelID := d.Get("channel_id").(string)
+	bookmark, err := client.AddBookmarkContext(ctx, channelID, slack.AddBookmarkParameters{
+		Title: d.Get("title").(string),
+		Type:  "link",
+		Link:  d.Get("link").(string),
+		Emoji: d.Get("emoji").(string),
+	})
+	if err != nil {
+		return diag.Errorf("couldn't add bookmark to conversation %s: %s", channelID, err)
+	}
+
+	d.SetId(conversationBookmarkID(channelID, bookmark.ID))
+	return resourceSlackConversationBookmarkRead(ctx, d, m)
+}
+
+func resourceSlackConversationBookmarkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id := d.Id()
+	channelID, bookmarkID, err := parseChannelScopedID(id, "bookmark_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	bookmarks, err := client.ListBookmarksContext(ctx, channelID)
+	if err != nil {
+		if err.Error() == "channel_not_found" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("channel with ID %s not found, removing from state", channelID),
+			})
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("couldn't list the bookmarks of conversation %s: %s", channelID, err)
+	}
+
+	for _, bookmark := range bookmarks {
+		if bookmark.ID == bookmarkID {
+			return updateBookmarkData(d, bookmark)
+		}
+	}
+
+	// deleted outside of Terraform, so it is added again on the next apply
+	diags = append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("bookmark %s not found in channel %s, removing from state", bookmarkID, channelID),
+	})
+	d.SetId("")
+	return diags
+}
+
+func resourceSlackConversationBookmarkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	channelID := d.Get("channel_id").(string)
+	bookmarkID := d.Get("bookmark_id").(string)
+	title := d.Get("title").(string)
+	emoji := d.Get("emoji").(string)
+	if _, err := client.EditBookmarkContext(ctx, channelID, bookmarkID, slack.EditBookmarkParameters{
+		Title: &title,
+		Emoji: &emoji,
+		Link:  d.Get("link").(string),
+	}); err != nil {
+		return diag.Errorf("couldn't edit bookmark %s of conversation %s: %s", bookmarkID, channelID, err)
+	}
+	return resourceSlackConversationBookmarkRead(ctx, d, m)
+}
+
+func resourceSlackConversationBookmarkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	channelID := d.Get("channel_id").(string)
+	bookmarkID := d.Get("bookmark_id").(string)
+	if err := client.RemoveBookmarkContext(ctx, channelID, bookmarkID); err != nil {
+		switch err.Error() {
+		case "channel_not_found", "not_found":
+		default:
+			return diag.Errorf("couldn't remove bookmark %s of conversation %s: %s", bookmarkID, channelID, err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+func updateBookmarkData(d *schema.ResourceData, bookmark slack.Bookmark) diag.Diagnostics {
+	if err := d.Set("channel_id", bookmark.ChannelID); err != nil {
+		return diag.Errorf("error setting channel_id: %s", err)
+	}
+	if err := d.Set("bookmark_id", bookmark.ID); err != nil {
+		return diag.Errorf("error setting bookmark_id: %s", err)
+	}
+	if err := d.Set("title", bookmark.Title); err != nil {
+		return diag.Errorf("error setting title: %s", err)
+	}
+	if err := d.Set("link", bookmark.Link); err != nil {
+		return diag.Errorf("error setting link: %s", err)
+	}
+	if err := d.Set("emoji", bookmark.Emoji); err != nil {
+		return diag.Errorf("error setting emoji: %s", err)
+	}
+	return nil
+}
+
+func conversationBookmarkID(channelID, bookmarkID string) string {
+	return channelID + "/" + bookmarkID
+}
@@ -0,0 +1,121 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConversationBookmarkScopes(t *testing.T) {
+	fake := testFakeSlack(t)
+	fake.setScopes("bookmarks:read")
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"channel_id": "C0123456789",
+		"title":      "Runbook",
+		"link":       "https://example.com/runbook",
+	})
+	_, err := resourceSlackConversationBookmark().SimpleDiff(context.Background(), nil, config, fake.providerMeta(t))
+	require.ErrorContains(t, err, "the Slack token is missing the bookmarks:write scope required by slack_conversation_bookmark")
+}
+
+func TestAccSlackConversationBookmark(t *testing.T) {
+	var providers []*schema.Provider
+	channel := createTestConversation(t)
+	resourceName := "slack_conversation_bookmark.test"
+	var bookmarkID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(&providers),
+		CheckDestroy:      testAccCheckConversationBookmarkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSlackConversationBookmarkConfig(channel.ID, "Runbook", "https://example.com/runbook", ":books:"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "channel_id", channel.ID),
+					resource.TestCheckResourceAttr(resourceName, "title", "Runbook"),
+					resource.TestCheckResourceAttr(resourceName, "link", "https://example.com/runbook"),
+					resource.TestCheckResourceAttr(resourceName, "emoji", ":books:"),
+					resource.TestCheckResourceAttrSet(resourceName, "bookmark_id"),
+					func(s *terraform.State) error {
+						bookmarkID = s.RootModule().Resources[resourceName].Primary.Attributes["bookmark_id"]
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccSlackConversationBookmarkConfig(channel.ID, "Dashboard", "https://example.com/dashboard", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "title", "Dashboard"),
+					resource.TestCheckResourceAttr(resourceName, "link", "https://example.com/dashboard"),
+					resource.TestCheckResourceAttr(resourceName, "emoji", ""),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources[resourceName].Primary.Attributes["bookmark_id"]; id != bookmarkID {
+							return fmt.Errorf("bookmark %s was replaced by %s instead of edited", bookmarkID, id)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// removed in the UI
+				PreConfig: func() {
+					client, err := testAccProvider.Meta().(*providerMeta).botClient()
+					require.NoError(t, err)
+					require.NoError(t, client.RemoveBookmarkContext(context.Background(), channel.ID, bookmarkID))
+				},
+				Config: testAccSlackConversationBookmarkConfig(channel.ID, "Dashboard", "https://example.com/dashboard", ""),
+				Check: func(s *terraform.State) error {
+					if id := s.RootModule().Resources[resourceName].Primary.Attributes["bookmark_id"]; id == bookmarkID {
+						return fmt.Errorf("bookmark %s was not added again", bookmarkID)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccSlackConversationBookmarkConfig(channelID, title, link, emoji string) string {
+	return fmt.Sprintf(`
+resource slack_conversation_bookmark test {
+  channel_id = "%s"
+  title      = "%s"
+  link       = "%s"
+  emoji      = "%s"
+}
+`, channelID, title, link, emoji)
+}
+
+func testAccCheckConversationBookmarkDestroy(s *terraform.State) error {
+	client, err := testAccProvider.Meta().(*providerMeta).botClient()
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "slack_conversation_bookmark" {
+			continue
+		}
+
+		bookmarks, err := client.ListBookmarksContext(context.Background(), rs.Primary.Attributes["channel_id"])
+		if err != nil {
+			return err
+		}
+		for _, bookmark := range bookmarks {
+			if bookmark.ID == rs.Primary.Attributes["bookmark_id"] {
+				return fmt.Errorf("bookmark %s still exists", rs.Primary.ID)
+			}
+		}
+	}
+	return nil
+}
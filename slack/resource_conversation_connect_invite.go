@@ -0,0 +1,260 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSlackConversationConnectInvite() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceSlackConversationConnectInviteRead,
+		CreateContext: resourceSlackConversationConnectInviteCreate,
+		UpdateContext: resourceSlackConversationConnectInviteRead,
+		DeleteContext: resourceSlackConversationConnectInviteDelete,
+		CustomizeDiff: customizeDiffConversationConnectInviteScopes,
+
+		Schema: map[string]*schema.Schema{
+			"channel_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"email": {
+				Type:         schema.TypeString,
+				Description:  "The email address of the external user to invite",
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"email", "user_id"},
+			},
+			"user_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the external user to invite",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"external_limited": {
+				Type:        schema.TypeBool,
+				Description: "Whether the external organization can only invite users of its own to the channel",
+				Optional:    true,
+				Default:     true,
+				ForceNew:    true,
+			},
+			"disconnect_on_destroy": {
+				Type:        schema.TypeBool,
+				Description: "Disconnect the organizations that accepted the invite from the channel on destroy, with the admin_token of the provider",
+				Optional:    true,
+				Default:     false,
+			},
+			"invite_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"accepted_team_ids": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+const connectInviteOutgoing = "outgoing"
+
+// connectInvite is an invite of conversations.listConnectInvites, either sent
+// by the workspace or received from another organization.
+type connectInvite struct {
+	Direction string `json:"direction"`
+	Status    string `json:"status"`
+	Invite    struct {
+		ID string `json:"id"`
+	} `json:"invite"`
+	Channel struct {
+		ID string `json:"id"`
+	} `json:"channel"`
+	Acceptances []connectInviteAcceptance `json:"acceptances"`
+}
+
+// connectInviteAcceptance is the answer of an external team to an invite.
+type connectInviteAcceptance struct {
+	ApprovalStatus string `json:"approval_status"`
+	AcceptingTeam  struct {
+		ID string `json:"id"`
+	} `json:"accepting_team"`
+}
+
+// customizeDiffConversationConnectInviteScopes checks the scopes of the bot
+// token, and those of the admin token when the organizations are disconnected
+// on destroy.
+func customizeDiffConversationConnectInviteScopes(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return err
+	}
+	if err := client.requireScopes("slack_conversation_connect_invite", "conversations.connect:write", "conversations.connect:read"); err != nil {
+		return err
+	}
+	if !d.Get("disconnect_on_destroy").(bool) {
+		return nil
+	}
+	adminClient, err := m.(*providerMeta).adminClient()
+	if err != nil {
+		return err
+	}
+	return adminClient.requireScopes("slack_conversation_connect_invite", "admin.conversations:write")
+}
+
+func resourceSlackConversationConnectInviteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	channelID := d.Get("channel_id").(string)
+	values := url.Values{
+		"channel":          {channelID},
+		"external_limited": {strconv.FormatBool(d.Get("external_limited").(bool))},
+	}
+	if email := d.Get("email").(string); email != "" {
+		values.Set("emails", email)
+	}
+	if userID := d.Get("user_id").(string); userID != "" {
+		values.Set("user_ids", userID)
+	}
+
+	var resp struct {
+		InviteID string `json:"invite_id"`
+	}
+	if _, err := client.call(ctx, "conversations.inviteShared", values, &resp); err != nil {
+		return diag.Errorf("couldn't invite to conversation %s: %s", channelID, err)
+	}
+
+	d.SetId(conversationConnectInviteID(channelID, resp.InviteID))
+	return resourceSlackConversationConnectInviteRead(ctx, d, m)
+}
+
+func resourceSlackConversationConnectInviteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id := d.Id()
+	channelID, inviteID, err := parseChannelScopedID(id, "invite_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	invite, err := findConnectInvite(ctx, client, inviteID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if invite == nil {
+		d.SetId("")
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("invite %s to channel %s not found, removing from state", inviteID, channelID),
+		}}
+	}
+
+	var acceptedTeamIDs []string
+	for _, acceptance := range invite.Acceptances {
+		if acceptance.ApprovalStatus == "approved" {
+			acceptedTeamIDs = append(acceptedTeamIDs, acceptance.AcceptingTeam.ID)
+		}
+	}
+
+	if err := d.Set("channel_id", channelID); err != nil {
+		return diag.Errorf("error setting channel_id: %s", err)
+	}
+	if err := d.Set("invite_id", inviteID); err != nil {
+		return diag.Errorf("error setting invite_id: %s", err)
+	}
+	if err := d.Set("status", invite.Status); err != nil {
+		return diag.Errorf("error setting status: %s", err)
+	}
+	if err := d.Set("accepted_team_ids", acceptedTeamIDs); err != nil {
+		return diag.Errorf("error setting accepted_team_ids: %s", err)
+	}
+	return nil
+}
+
+func resourceSlackConversationConnectInviteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if !d.Get("disconnect_on_destroy").(bool) {
+		d.SetId("")
+		return nil
+	}
+
+	channelID := d.Get("channel_id").(string)
+	teamIDs := schemaSetToSlice(d.Get("accepted_team_ids").(*schema.Set))
+	if len(teamIDs) == 0 {
+		d.SetId("")
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("invite %s to channel %s wasn't accepted, no organization to disconnect", d.Get("invite_id"), channelID),
+			Detail:   "Slack has no API to revoke a pending invite, it can be revoked in the channel settings.",
+		}}
+	}
+
+	client, err := m.(*providerMeta).adminClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := client.call(ctx, "admin.conversations.disconnectShared", url.Values{
+		"channel_id":       {channelID},
+		"leaving_team_ids": {strings.Join(teamIDs, ",")},
+	}, nil); err != nil {
+		switch err.Error() {
+		case "channel_not_found", "not_an_enterprise", "team_not_found":
+		default:
+			return diag.Errorf("couldn't disconnect %s from conversation %s: %s", strings.Join(teamIDs, ", "), channelID, err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+// findConnectInvite returns the outgoing Slack Connect invite with the given
+// ID, or nil if there is none, going through every page of
+// conversations.listConnectInvites.
+func findConnectInvite(ctx context.Context, client *apiClient, inviteID string) (*connectInvite, error) {
+	cursor := ""
+	for {
+		var resp struct {
+			Invites          []connectInvite `json:"invites"`
+			ResponseMetadata struct {
+				NextCursor string `json:"next_cursor"`
+			} `json:"response_metadata"`
+		}
+		values := url.Values{"count": {strconv.Itoa(cursorLimit)}}
+		if cursor != "" {
+			values.Set("cursor", cursor)
+		}
+		if _, err := client.call(ctx, "conversations.listConnectInvites", values, &resp); err != nil {
+			return nil, fmt.Errorf("couldn't list the Slack Connect invites: %w", err)
+		}
+		for i := range resp.Invites {
+			if resp.Invites[i].Direction == connectInviteOutgoing && resp.Invites[i].Invite.ID == inviteID {
+				return &resp.Invites[i], nil
+			}
+		}
+		if resp.ResponseMetadata.NextCursor == "" {
+			return nil, nil
+		}
+		cursor = resp.ResponseMetadata.NextCursor
+	}
+}
+
+func conversationConnectInviteID(channelID, inviteID string) string {
+	return channelID + "/" + inviteID
+}
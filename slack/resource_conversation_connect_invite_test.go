@@ -0,0 +1,129 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConversationConnectInvite(t *testing.T) {
+	fake := testFakeSlack(t)
+	meta := fake.providerMeta(t)
+	meta.clients[tokenAdmin] = meta.clients[tokenDefault]
+	channelID := fake.addChannel("partners", false)
+
+	create := func(t *testing.T) *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, resourceSlackConversationConnectInvite().Schema, map[string]interface{}{
+			"channel_id":            channelID,
+			"email":                 "someone@partner.example.com",
+			"disconnect_on_destroy": true,
+		})
+		diags := resourceSlackConversationConnectInviteCreate(context.Background(), d, meta)
+		require.False(t, diags.HasError(), "%v", diags)
+		return d
+	}
+
+	t.Run("tracks the status and disconnects on destroy", func(t *testing.T) {
+		d := create(t)
+		require.Equal(t, conversationConnectInviteID(channelID, d.Get("invite_id").(string)), d.Id())
+		require.Equal(t, "pending", d.Get("status"))
+		require.Equal(t, 0, d.Get("accepted_team_ids").(*schema.Set).Len())
+
+		fake.acceptConnectInvite(d.Get("invite_id").(string), "T0PARTNER")
+		diags := resourceSlackConversationConnectInviteRead(context.Background(), d, meta)
+		require.False(t, diags.HasError(), "%v", diags)
+		require.Equal(t, "approved", d.Get("status"))
+		require.Equal(t, []string{"T0PARTNER"}, schemaSetToSlice(d.Get("accepted_team_ids").(*schema.Set)))
+
+		diags = resourceSlackConversationConnectInviteDelete(context.Background(), d, meta)
+		require.Empty(t, diags)
+		require.Empty(t, fake.sharedTeams[channelID])
+		require.Equal(t, 1, fake.callCount("admin.conversations.disconnectShared"))
+	})
+
+	t.Run("stays in the state when the disconnect fails", func(t *testing.T) {
+		d := create(t)
+		fake.acceptConnectInvite(d.Get("invite_id").(string), "T0PARTNER")
+		diags := resourceSlackConversationConnectInviteRead(context.Background(), d, meta)
+		require.False(t, diags.HasError(), "%v", diags)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		diags = resourceSlackConversationConnectInviteDelete(ctx, d, meta)
+		require.True(t, diags.HasError())
+		require.NotEmpty(t, d.Id())
+		require.Equal(t, []string{"T0PARTNER"}, fake.sharedTeams[channelID])
+	})
+
+	t.Run("ignores the invites received from other organizations", func(t *testing.T) {
+		var incoming connectInvite
+		incoming.Direction = "incoming"
+		incoming.Status = "pending"
+		incoming.Invite.ID = "I0INCOMING"
+		incoming.Channel.ID = channelID
+		fake.connectInvites = append(fake.connectInvites, incoming)
+
+		client, err := meta.botClient()
+		require.NoError(t, err)
+		invite, err := findConnectInvite(context.Background(), client, "I0INCOMING")
+		require.NoError(t, err)
+		require.Nil(t, invite)
+	})
+
+	t.Run("pending invites have no organization to disconnect", func(t *testing.T) {
+		d := create(t)
+		diags := resourceSlackConversationConnectInviteDelete(context.Background(), d, meta)
+		require.Len(t, diags, 1)
+		require.Equal(t, diag.Warning, diags[0].Severity)
+		require.Equal(t, 1, fake.callCount("admin.conversations.disconnectShared"))
+	})
+}
+
+func TestConversationConnectInviteScopes(t *testing.T) {
+	fake := testFakeSlack(t)
+	fake.setScopes("conversations.connect:read", "conversations.connect:write")
+	meta := fake.providerMeta(t)
+	diff := func(config map[string]interface{}) error {
+		config["channel_id"] = "C0123456789"
+		config["email"] = "partner@example.com"
+		_, err := resourceSlackConversationConnectInvite().SimpleDiff(context.Background(), nil, terraform.NewResourceConfigRaw(config), meta)
+		return err
+	}
+
+	require.NoError(t, diff(map[string]interface{}{}))
+	require.ErrorContains(t, diff(map[string]interface{}{"disconnect_on_destroy": true}),
+		"the Slack token is missing the admin.conversations:write scope required by slack_conversation_connect_invite")
+}
+
+func TestAccSlackConversationConnectInvite(t *testing.T) {
+	var providers []*schema.Provider
+	channel := createTestConversation(t)
+	resourceName := "slack_conversation_connect_invite.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(&providers),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource slack_conversation_connect_invite test {
+  channel_id = "%s"
+  email      = "%s"
+}
+`, channel.ID, "connect-invite@example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "channel_id", channel.ID),
+					resource.TestCheckResourceAttrSet(resourceName, "invite_id"),
+					resource.TestCheckResourceAttr(resourceName, "status", "pending"),
+					resource.TestCheckResourceAttr(resourceName, "accepted_team_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
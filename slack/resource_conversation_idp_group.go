@@ -0,0 +1,151 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSlackConversationIDPGroup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceSlackConversationIDPGroupRead,
+		CreateContext: resourceSlackConversationIDPGroupCreate,
+		DeleteContext: resourceSlackConversationIDPGroupDelete,
+		CustomizeDiff: func(_ context.Context, _ *schema.ResourceDiff, m interface{}) error {
+			client, err := m.(*providerMeta).adminClient()
+			if err != nil {
+				return err
+			}
+			return client.requireScopes("slack_conversation_idp_group", "admin.conversations:write", "admin.conversations:read")
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"channel_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"group_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the IDP group allowed in the private conversation",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"team_id": {
+				Type:        schema.TypeString,
+				Description: "The workspace of an Enterprise Grid org the conversation belongs to. Defaults to the team_id of the provider",
+				Optional:    true,
+				ForceNew:    true,
+			},
+		},
+	}
+}
+
+func resourceSlackConversationIDPGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).adminClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	channelID := d.Get("channel_id").(string)
+	groupID := d.Get("group_id").(string)
+	if _, err := client.call(ctx, "admin.conversations.restrictAccess.addGroup",
+		conversationIDPGroupValues(m.(*providerMeta).resourceTeamID(d), channelID, groupID), nil); err != nil {
+		return diag.Errorf("couldn't restrict conversation %s to IDP group %s: %s", channelID, groupID, err)
+	}
+
+	d.SetId(conversationIDPGroupID(channelID, groupID))
+	return resourceSlackConversationIDPGroupRead(ctx, d, m)
+}
+
+func resourceSlackConversationIDPGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).adminClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id := d.Id()
+	channelID, groupID, err := parseChannelScopedID(id, "group_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	var resp struct {
+		GroupIDs []string `json:"group_ids"`
+	}
+	if _, err := client.call(ctx, "admin.conversations.restrictAccess.listGroups",
+		conversationIDPGroupValues(m.(*providerMeta).resourceTeamID(d), channelID, ""), &resp); err != nil {
+		if err.Error() == "channel_not_found" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("channel with ID %s not found, removing from state", channelID),
+			})
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("couldn't list the IDP groups of conversation %s: %s", channelID, err)
+	}
+	// removed in the admin UI
+	if !contains(resp.GroupIDs, groupID) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("channel with ID %s isn't restricted to IDP group %s, removing from state", channelID, groupID),
+		})
+		d.SetId("")
+		return diags
+	}
+
+	if err := d.Set("channel_id", channelID); err != nil {
+		return diag.Errorf("error setting channel_id: %s", err)
+	}
+	if err := d.Set("group_id", groupID); err != nil {
+		return diag.Errorf("error setting group_id: %s", err)
+	}
+	return diags
+}
+
+func resourceSlackConversationIDPGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).adminClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	channelID := d.Get("channel_id").(string)
+	groupID := d.Get("group_id").(string)
+	if _, err := client.call(ctx, "admin.conversations.restrictAccess.removeGroup",
+		conversationIDPGroupValues(m.(*providerMeta).resourceTeamID(d), channelID, groupID), nil); err != nil {
+		switch err.Error() {
+		case "channel_not_found", "group_not_found":
+		default:
+			return diag.Errorf("couldn't remove IDP group %s from conversation %s: %s", groupID, channelID, err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+// conversationIDPGroupValues returns the arguments of the
+// admin.conversations.restrictAccess methods. The team_id is only sent when
+// known, as it is not needed outside of Enterprise Grid orgs.
+func conversationIDPGroupValues(teamID, channelID, groupID string) url.Values {
+	values := url.Values{"channel_id": {channelID}}
+	if groupID != "" {
+		values.Set("group_id", groupID)
+	}
+	if teamID != "" {
+		values.Set("team_id", teamID)
+	}
+	return values
+}
+
+func conversationIDPGroupID(channelID, groupID string) string {
+	return channelID + "/" + groupID
+}
@@ -0,0 +1,125 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+// testIDPGroupID is an IDP group of the org the acceptance tests run against.
+const testIDPGroupID = "S05IDPGROUP"
+
+func TestConversationIDPGroupScopes(t *testing.T) {
+	fake := testFakeSlack(t)
+	fake.setScopes("admin.conversations:write")
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"channel_id": "C0123456789",
+		"group_id":   testIDPGroupID,
+	})
+	_, err := resourceSlackConversationIDPGroup().SimpleDiff(context.Background(), nil, config, fake.providerMeta(t))
+	require.ErrorContains(t, err, "the Slack token is missing the admin.conversations:read scope required by slack_conversation_idp_group")
+}
+
+func TestAccSlackConversationIDPGroup(t *testing.T) {
+	var providers []*schema.Provider
+	channel := createTestConversation(t)
+	resourceName := "slack_conversation_idp_group.test"
+	config := fmt.Sprintf(`
+resource slack_conversation_idp_group test {
+  channel_id = "%s"
+  group_id   = "%s"
+}
+`, channel.ID, testIDPGroupID)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckAdmin(t) },
+		ProviderFactories: testAccProviderFactories(&providers),
+		CheckDestroy:      testAccCheckConversationIDPGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", conversationIDPGroupID(channel.ID, testIDPGroupID)),
+					resource.TestCheckResourceAttr(resourceName, "channel_id", channel.ID),
+					resource.TestCheckResourceAttr(resourceName, "group_id", testIDPGroupID),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// removed in the admin UI
+				PreConfig: func() {
+					client, err := testAccProvider.Meta().(*providerMeta).adminClient()
+					require.NoError(t, err)
+					_, err = client.call(context.Background(), "admin.conversations.restrictAccess.removeGroup", url.Values{
+						"channel_id": {channel.ID},
+						"group_id":   {testIDPGroupID},
+					}, nil)
+					require.NoError(t, err)
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check:  testAccCheckConversationIDPGroups(channel.ID, testIDPGroupID),
+			},
+		},
+	})
+}
+
+func testAccCheckConversationIDPGroups(channelID string, groupIDs ...string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		actual, err := testAccListConversationIDPGroups(channelID)
+		if err != nil {
+			return err
+		}
+		for _, groupID := range groupIDs {
+			if !contains(actual, groupID) {
+				return fmt.Errorf("channel %s isn't restricted to IDP group %s", channelID, groupID)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckConversationIDPGroupDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "slack_conversation_idp_group" {
+			continue
+		}
+
+		groupIDs, err := testAccListConversationIDPGroups(rs.Primary.Attributes["channel_id"])
+		if err != nil {
+			return err
+		}
+		if contains(groupIDs, rs.Primary.Attributes["group_id"]) {
+			return fmt.Errorf("IDP group %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccListConversationIDPGroups(channelID string) ([]string, error) {
+	client, err := testAccProvider.Meta().(*providerMeta).adminClient()
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		GroupIDs []string `json:"group_ids"`
+	}
+	if _, err := client.call(context.Background(), "admin.conversations.restrictAccess.listGroups", url.Values{"channel_id": {channelID}}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.GroupIDs, nil
+}
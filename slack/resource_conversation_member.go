@@ -0,0 +1,180 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/slack-go/slack"
+)
+
+func resourceSlackConversationMember() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceSlackConversationMemberRead,
+		CreateContext: resourceSlackConversationMemberCreate,
+		DeleteContext: resourceSlackConversationMemberDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"channel_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceSlackConversationMemberCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	channelID := d.Get("channel_id").(string)
+	userID := d.Get("user_id").(string)
+	if err := setConversationMembers(ctx, client, channelID, []string{userID}, false, ignoredMembers{}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(conversationMemberID(channelID, userID))
+	return resourceSlackConversationMemberRead(ctx, d, m)
+}
+
+func resourceSlackConversationMemberRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id := d.Id()
+	channelID, userID, err := parseConversationMemberID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	isMember, err := isConversationMember(ctx, client, channelID, userID)
+	if err != nil {
+		if err.Error() == "channel_not_found" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("channel with ID %s not found, removing from state", channelID),
+			})
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("couldn't get users in conversation for %s: %s", channelID, err)
+	}
+	if !isMember {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("user %s is not a member of channel %s, removing from state", userID, channelID),
+		})
+		d.SetId("")
+		return diags
+	}
+
+	if err := d.Set("channel_id", channelID); err != nil {
+		return diag.Errorf("error setting channel_id: %s", err)
+	}
+	if err := d.Set("user_id", userID); err != nil {
+		return diag.Errorf("error setting user_id: %s", err)
+	}
+	return diags
+}
+
+func resourceSlackConversationMemberDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := removeConversationMember(ctx, client, d.Get("channel_id").(string), d.Get("user_id").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return nil
+}
+
+// isConversationMember returns whether the user is a member of the
+// conversation, going through the pages of conversations.members until the
+// user is found.
+func isConversationMember(ctx context.Context, client *apiClient, channelID, userID string) (bool, error) {
+	cursor := ""
+	for {
+		users, nextCursor, err := client.GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
+			ChannelID: channelID,
+			Cursor:    cursor,
+			Limit:     cursorLimit,
+		})
+		if err != nil {
+			return false, err
+		}
+		if contains(users, userID) {
+			return true, nil
+		}
+		if nextCursor == "" {
+			return false, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+// removeConversationMember kicks a user from a conversation, or leaves it when
+// the user is the API user. Users who already left aren't an error.
+func removeConversationMember(ctx context.Context, client *apiClient, channelID, userID string) error {
+	apiUserInfo, err := client.identity(ctx)
+	if err != nil {
+		return err
+	}
+
+	if userID == apiUserInfo.userID {
+		if _, err := client.LeaveConversationContext(ctx, channelID); err != nil && !isConversationGone(err) {
+			return fmt.Errorf("api user couldn't leave conversation %s: %w", channelID, err)
+		}
+		return nil
+	}
+	if err := client.KickUserFromConversationContext(ctx, channelID, userID); err != nil && !isConversationGone(err) {
+		return fmt.Errorf("couldn't kick user %s from conversation %s: %w", userID, channelID, err)
+	}
+	return nil
+}
+
+// isConversationGone returns whether err means that the user is no longer in
+// the conversation, or that the conversation doesn't exist anymore.
+func isConversationGone(err error) bool {
+	switch err.Error() {
+	case "not_in_channel", "channel_not_found", "is_archived":
+		return true
+	}
+	return false
+}
+
+func conversationMemberID(channelID, userID string) string {
+	return channelID + "/" + userID
+}
+
+func parseConversationMemberID(id string) (string, string, error) {
+	return parseChannelScopedID(id, "user_id")
+}
+
+// parseChannelScopedID splits the ID of a resource that belongs to a channel,
+// formatted as <channel_id>/<name>.
+func parseChannelScopedID(id, name string) (string, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid ID %q, expected <channel_id>/<%s>", id, name)
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,113 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConversationMemberID(t *testing.T) {
+	channelID, userID, err := parseConversationMemberID(conversationMemberID("C0123", "U0456"))
+	require.NoError(t, err)
+	require.Equal(t, "C0123", channelID)
+	require.Equal(t, "U0456", userID)
+
+	for _, id := range []string{"C0123", "C0123/", "/U0456", "C0123/U0456/U0789"} {
+		_, _, err := parseConversationMemberID(id)
+		require.EqualError(t, err, fmt.Sprintf("invalid ID %q, expected <channel_id>/<user_id>", id))
+	}
+}
+
+func TestConversationMemberRead(t *testing.T) {
+	fake := testFakeSlack(t)
+	meta := fake.providerMeta(t)
+	members := []string{testUser00.id}
+	for i := 0; i < 2*cursorLimit; i++ {
+		members = append(members, fmt.Sprintf("U%010d", i))
+	}
+	channelID := fake.addChannel("large", false, members...)
+
+	read := func(userID string) *schema.ResourceData {
+		d := resourceSlackConversationMember().TestResourceData()
+		d.SetId(conversationMemberID(channelID, userID))
+		diags := resourceSlackConversationMemberRead(context.Background(), d, meta)
+		require.False(t, diags.HasError(), "%v", diags)
+		return d
+	}
+
+	calls := fake.callCount("conversations.members")
+	require.NotEmpty(t, read(testUser00.id).Id())
+	require.Equal(t, calls+1, fake.callCount("conversations.members"), "stops at the page of the member")
+
+	require.Empty(t, read(testUser01.id).Id())
+	require.Equal(t, calls+4, fake.callCount("conversations.members"))
+}
+
+func TestAccSlackConversationMember(t *testing.T) {
+	var providers []*schema.Provider
+	channel := createTestConversation(t)
+	resourceName := "slack_conversation_member.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(&providers),
+		CheckDestroy:      testAccCheckConversationMembers(channel.ID, nil, []string{testUser00.id}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSlackConversationMemberConfig(channel.ID, testUser00.id),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", conversationMemberID(channel.ID, testUser00.id)),
+					resource.TestCheckResourceAttr(resourceName, "channel_id", channel.ID),
+					resource.TestCheckResourceAttr(resourceName, "user_id", testUser00.id),
+					testAccCheckConversationMembers(channel.ID, []string{testUser00.id}, []string{testUser01.id}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     conversationMemberID(channel.ID, testUser00.id),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSlackConversationMemberConfig(channelID, userID string) string {
+	return fmt.Sprintf(`
+resource slack_conversation_member test {
+  channel_id = "%s"
+  user_id    = "%s"
+}
+`, channelID, userID)
+}
+
+// testAccCheckConversationMembers checks that the members of a conversation
+// include the users in members, and none of the users in notMembers.
+func testAccCheckConversationMembers(channelID string, members, notMembers []string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client, err := testAccProvider.Meta().(*providerMeta).botClient()
+		if err != nil {
+			return err
+		}
+		users, err := getConversationMembers(context.Background(), client, channelID)
+		if err != nil {
+			return fmt.Errorf("couldn't get users in conversation for %s: %s", channelID, err)
+		}
+		for _, user := range members {
+			if !contains(users, user) {
+				return fmt.Errorf("user %s is not a member of channel %s", user, channelID)
+			}
+		}
+		for _, user := range notMembers {
+			if contains(users, user) {
+				return fmt.Errorf("user %s is still a member of channel %s", user, channelID)
+			}
+		}
+		return nil
+	}
+}
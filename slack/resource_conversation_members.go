@@ -0,0 +1,160 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/slack-go/slack"
+)
+
+func resourceSlackConversationMembers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceSlackConversationMembersRead,
+		CreateContext: resourceSlackConversationMembersCreate,
+		UpdateContext: resourceSlackConversationMembersUpdate,
+		DeleteContext: resourceSlackConversationMembersDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"channel_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"members": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:      schema.HashString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceSlackConversationMembersCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	channelID := d.Get("channel_id").(string)
+	if err := setConversationMembers(ctx, client, channelID, schemaSetToSlice(d.Get("members").(*schema.Set)), true, ignoredMembers{}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(channelID)
+	return resourceSlackConversationMembersRead(ctx, d, m)
+}
+
+func resourceSlackConversationMembersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id := d.Id()
+	var diags diag.Diagnostics
+	channel, err := client.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
+		ChannelID: id,
+	})
+	if err != nil {
+		if err.Error() == "channel_not_found" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("channel with ID %s not found, removing from state", id),
+			})
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("couldn't get conversation info for %s: %s", id, err)
+	}
+
+	users, err := getConversationMembers(ctx, client, channel.ID)
+	if err != nil {
+		return diag.Errorf("couldn't get users in conversation for %s: %s", channel.ID, err)
+	}
+	apiUserInfo, err := client.identity(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	previous := schemaSetToSlice(d.Get("members").(*schema.Set))
+	if err := d.Set("channel_id", channel.ID); err != nil {
+		return diag.Errorf("error setting channel_id: %s", err)
+	}
+	if err := d.Set("members", actualPermanentMembers(previous, users, channel.Creator, apiUserInfo.userID)); err != nil {
+		return diag.Errorf("error setting members: %s", err)
+	}
+	return diags
+}
+
+func resourceSlackConversationMembersUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("members") {
+		if err := setConversationMembers(ctx, client, d.Id(), schemaSetToSlice(d.Get("members").(*schema.Set)), true, ignoredMembers{}); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceSlackConversationMembersRead(ctx, d, m)
+}
+
+func resourceSlackConversationMembersDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id := d.Id()
+	channel, err := client.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
+		ChannelID: id,
+	})
+	if err != nil {
+		if isConversationGone(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("couldn't get conversation info for %s: %s", id, err)
+	}
+	if channel.IsArchived {
+		d.SetId("")
+		return nil
+	}
+
+	apiUserInfo, err := client.identity(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// the API user is kicked last, as it can't kick anyone once it left
+	var leave bool
+	for _, userID := range schemaSetToSlice(d.Get("members").(*schema.Set)) {
+		switch userID {
+		case channel.Creator:
+			continue
+		case apiUserInfo.userID:
+			leave = true
+			continue
+		}
+		if err := removeConversationMember(ctx, client, id, userID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if leave {
+		if err := removeConversationMember(ctx, client, id, apiUserInfo.userID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
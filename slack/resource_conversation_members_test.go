@@ -0,0 +1,55 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccSlackConversationMembers(t *testing.T) {
+	var providers []*schema.Provider
+	channel := createTestConversation(t)
+	resourceName := "slack_conversation_members.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(&providers),
+		CheckDestroy:      testAccCheckConversationMembers(channel.ID, nil, []string{testUser00.id, testUser01.id}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSlackConversationMembersConfig(channel.ID, testUser00.id),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", channel.ID),
+					resource.TestCheckResourceAttr(resourceName, "members.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "members.*", testUser00.id),
+					testAccCheckConversationMembers(channel.ID, []string{testUser00.id}, []string{testUser01.id}),
+				),
+			},
+			{
+				Config: testAccSlackConversationMembersConfig(channel.ID, testUser01.id),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "members.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "members.*", testUser01.id),
+					testAccCheckConversationMembers(channel.ID, []string{testUser01.id}, []string{testUser00.id}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSlackConversationMembersConfig(channelID string, members ...string) string {
+	return fmt.Sprintf(`
+resource slack_conversation_members test {
+  channel_id = "%s"
+  members    = ["%s"]
+}
+`, channelID, strings.Join(members, `", "`))
+}
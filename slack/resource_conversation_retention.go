@@ -0,0 +1,137 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceSlackConversationRetention() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceSlackConversationRetentionRead,
+		CreateContext: resourceSlackConversationRetentionCreate,
+		UpdateContext: resourceSlackConversationRetentionUpdate,
+		DeleteContext: resourceSlackConversationRetentionDelete,
+		CustomizeDiff: func(_ context.Context, _ *schema.ResourceDiff, m interface{}) error {
+			client, err := m.(*providerMeta).adminClient()
+			if err != nil {
+				return err
+			}
+			return client.requireScopes("slack_conversation_retention", "admin.conversations:write", "admin.conversations:read")
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"channel_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"duration_days": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The number of days the messages of the conversation are kept",
+			},
+		},
+	}
+}
+
+func resourceSlackConversationRetentionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	channelID := d.Get("channel_id").(string)
+	if err := setConversationRetention(ctx, m.(*providerMeta), channelID, d.Get("duration_days").(int)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(channelID)
+	return resourceSlackConversationRetentionRead(ctx, d, m)
+}
+
+func resourceSlackConversationRetentionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).adminClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id := d.Id()
+	var diags diag.Diagnostics
+	var resp struct {
+		IsPolicyEnabled bool `json:"is_policy_enabled"`
+		DurationDays    int  `json:"duration_days"`
+	}
+	if _, err := client.call(ctx, "admin.conversations.getCustomRetention", url.Values{"channel_id": {id}}, &resp); err != nil {
+		if err.Error() == "channel_not_found" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("channel with ID %s not found, removing from state", id),
+			})
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("couldn't get the custom retention of conversation %s: %s", id, err)
+	}
+	if !resp.IsPolicyEnabled {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("channel with ID %s has no custom retention, removing from state", id),
+		})
+		d.SetId("")
+		return diags
+	}
+
+	if err := d.Set("channel_id", id); err != nil {
+		return diag.Errorf("error setting channel_id: %s", err)
+	}
+	if err := d.Set("duration_days", resp.DurationDays); err != nil {
+		return diag.Errorf("error setting duration_days: %s", err)
+	}
+	return diags
+}
+
+func resourceSlackConversationRetentionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.HasChange("duration_days") {
+		if err := setConversationRetention(ctx, m.(*providerMeta), d.Id(), d.Get("duration_days").(int)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceSlackConversationRetentionRead(ctx, d, m)
+}
+
+func resourceSlackConversationRetentionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).adminClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id := d.Id()
+	if _, err := client.call(ctx, "admin.conversations.removeCustomRetention", url.Values{"channel_id": {id}}, nil); err != nil {
+		if err.Error() != "channel_not_found" {
+			return diag.Errorf("couldn't remove the custom retention of conversation %s: %s", id, err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+func setConversationRetention(ctx context.Context, m *providerMeta, channelID string, durationDays int) error {
+	client, err := m.adminClient()
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.call(ctx, "admin.conversations.setCustomRetention", url.Values{
+		"channel_id":    {channelID},
+		"duration_days": {strconv.Itoa(durationDays)},
+	}, nil); err != nil {
+		return fmt.Errorf("couldn't set the custom retention of conversation %s: %w", channelID, err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConversationRetentionScopes(t *testing.T) {
+	fake := testFakeSlack(t)
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"channel_id":    "C0123456789",
+		"duration_days": 30,
+	})
+	for granted, missing := range map[string]string{
+		"admin.conversations:read":  "admin.conversations:write",
+		"admin.conversations:write": "admin.conversations:read",
+	} {
+		fake.setScopes(granted)
+		_, err := resourceSlackConversationRetention().SimpleDiff(context.Background(), nil, config, fake.providerMeta(t))
+		require.ErrorContains(t, err, "the Slack token is missing the "+missing+" scope required by slack_conversation_retention")
+	}
+}
+
+func TestAccSlackConversationRetention(t *testing.T) {
+	var providers []*schema.Provider
+	channel := createTestConversation(t)
+	resourceName := "slack_conversation_retention.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheckAdmin(t) },
+		ProviderFactories: testAccProviderFactories(&providers),
+		CheckDestroy:      testAccCheckConversationRetentionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSlackConversationRetentionConfig(channel.ID, 30),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", channel.ID),
+					resource.TestCheckResourceAttr(resourceName, "channel_id", channel.ID),
+					resource.TestCheckResourceAttr(resourceName, "duration_days", "30"),
+				),
+			},
+			{
+				Config: testAccSlackConversationRetentionConfig(channel.ID, 90),
+				Check:  resource.TestCheckResourceAttr(resourceName, "duration_days", "90"),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSlackConversationRetentionConfig(channelID string, durationDays int) string {
+	return fmt.Sprintf(`
+resource slack_conversation_retention test {
+  channel_id    = "%s"
+  duration_days = %d
+}
+`, channelID, durationDays)
+}
+
+func testAccCheckConversationRetentionDestroy(s *terraform.State) error {
+	client, err := testAccProvider.Meta().(*providerMeta).adminClient()
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "slack_conversation_retention" {
+			continue
+		}
+
+		var resp struct {
+			IsPolicyEnabled bool `json:"is_policy_enabled"`
+		}
+		if _, err := client.call(context.Background(), "admin.conversations.getCustomRetention", url.Values{"channel_id": {rs.Primary.ID}}, &resp); err != nil {
+			return err
+		}
+		if resp.IsPolicyEnabled {
+			return fmt.Errorf("channel %s still has a custom retention", rs.Primary.ID)
+		}
+	}
+	return nil
+}
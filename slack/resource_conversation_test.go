@@ -8,9 +8,11 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 
 	"github.com/hashicorp/go-multierror"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -73,17 +75,17 @@ func TestAccSlackConversationTest(t *testing.T) {
 	resourceName := "slack_conversation.%s"
 
 	t.Run("update name, topic and purpose", func(t *testing.T) {
-		name := acctest.RandomWithPrefix(conversationNamePrefix)
+		name := testAccRandomName(t, conversationNamePrefix)
 		createChannel := testAccSlackConversation(name)
 
-		updateName := acctest.RandomWithPrefix(conversationNamePrefix)
+		updateName := testAccRandomName(t, conversationNamePrefix)
 		updateChannel := testAccSlackConversation(updateName)
 
 		testSlackConversationUpdate(t, fmt.Sprintf(resourceName, name), createChannel, &updateChannel)
 	})
 
 	t.Run("archive channel", func(t *testing.T) {
-		name := acctest.RandomWithPrefix(conversationNamePrefix)
+		name := testAccRandomName(t, conversationNamePrefix)
 		createChannel := testAccSlackConversationWithMembers(name, []string{testUser00.id})
 
 		updateChannel := createChannel
@@ -93,7 +95,7 @@ func TestAccSlackConversationTest(t *testing.T) {
 	})
 
 	t.Run("unarchive channel", func(t *testing.T) {
-		name := acctest.RandomWithPrefix(conversationNamePrefix)
+		name := testAccRandomName(t, conversationNamePrefix)
 		createChannel := testAccSlackConversationWithMembers(name, []string{testUser00.id})
 		createChannel.IsArchived = true
 
@@ -104,7 +106,7 @@ func TestAccSlackConversationTest(t *testing.T) {
 	})
 
 	t.Run("add permanent members", func(t *testing.T) {
-		name := acctest.RandomWithPrefix(conversationNamePrefix)
+		name := testAccRandomName(t, conversationNamePrefix)
 		createChannel := testAccSlackConversationWithMembers(name, []string{testUser00.id})
 
 		updateChannel := createChannel
@@ -114,7 +116,7 @@ func TestAccSlackConversationTest(t *testing.T) {
 	})
 
 	t.Run("remove permanent members", func(t *testing.T) {
-		name := acctest.RandomWithPrefix(conversationNamePrefix)
+		name := testAccRandomName(t, conversationNamePrefix)
 		createChannel := testAccSlackConversationWithMembers(name, []string{testUser00.id, testUser01.id})
 
 		updateChannel := createChannel
@@ -124,7 +126,7 @@ func TestAccSlackConversationTest(t *testing.T) {
 	})
 
 	t.Run("invite only the creator to the channel", func(t *testing.T) {
-		name := acctest.RandomWithPrefix(conversationNamePrefix)
+		name := testAccRandomName(t, conversationNamePrefix)
 		users := []string{testUserCreator.id}
 		createChannel := testAccSlackConversationWithMembers(name, users)
 
@@ -132,7 +134,7 @@ func TestAccSlackConversationTest(t *testing.T) {
 	})
 
 	t.Run("invite creator and other users to the channel", func(t *testing.T) {
-		name := acctest.RandomWithPrefix(conversationNamePrefix)
+		name := testAccRandomName(t, conversationNamePrefix)
 		users := []string{testUserCreator.id, testUser00.id, testUser01.id}
 		createChannel := testAccSlackConversationWithMembers(name, users)
 
@@ -154,7 +156,7 @@ func testSlackConversationUpdate(t *testing.T, resourceName string, createChanne
 			ResourceName:            resourceName,
 			ImportState:             true,
 			ImportStateVerify:       true,
-			ImportStateVerifyIgnore: []string{"permanent_members", "action_on_destroy", "action_on_update_permanent_members", "adopt_existing_channel"},
+			ImportStateVerifyIgnore: []string{"permanent_members", "team_id"},
 		},
 	}
 
@@ -203,7 +205,10 @@ func testCheckSlackChannelAttributes(t *testing.T, resourceName string, expected
 			return fmt.Errorf("not found: %s", resourceName)
 		}
 
-		c := testAccProvider.Meta().(*slack.Client)
+		c, err := testAccProvider.Meta().(*providerMeta).botClient()
+		if err != nil {
+			return err
+		}
 		primary := rs.Primary
 		channel, err := c.GetConversationInfo(&slack.GetConversationInfoInput{
 			ChannelID: primary.ID,
@@ -293,7 +298,10 @@ func testAccSlackConversationWithMembers(channelName string, members []string) s
 }
 
 func testAccCheckConversationDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*slack.Client)
+	c, err := testAccProvider.Meta().(*providerMeta).botClient()
+	if err != nil {
+		return err
+	}
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "slack_conversation" {
 			continue
@@ -329,3 +337,511 @@ resource slack_conversation %s {
 func testAccSlackConversationConfig(c slack.Channel) string {
 	return testAccSlackConversationConfigWithResourceName(c, c.Name)
 }
+
+func TestConversationTeamID(t *testing.T) {
+	fake := testFakeSlack(t)
+	meta := fake.providerMeta(t)
+	meta.teamID = "T0GRIDTEAM"
+	client, err := meta.botClient()
+	require.NoError(t, err)
+
+	create := func(raw map[string]interface{}) *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, resourceSlackConversation().Schema, raw)
+		diags := resourceSlackConversationCreate(context.Background(), d, meta)
+		require.False(t, diags.HasError(), "%v", diags)
+		return d
+	}
+
+	d := create(map[string]interface{}{"name": "grid-channel", "is_private": false})
+	require.Equal(t, "T0GRIDTEAM", d.Get("team_id"), "defaults to the team_id of the provider")
+
+	adopted := create(map[string]interface{}{"name": "grid-channel", "is_private": false, "adopt_existing_channel": true})
+	require.Equal(t, d.Id(), adopted.Id())
+
+	channel, err := findExistingChannel(context.Background(), client, "grid-channel", false, "T0GRIDTEAM")
+	require.NoError(t, err)
+	require.Equal(t, d.Id(), channel.ID)
+
+	_, err = findExistingChannel(context.Background(), client, "grid-channel", false, "T0OTHERTEAM")
+	require.EqualError(t, err, "could not find channel with name grid-channel")
+}
+
+func TestConversationScopes(t *testing.T) {
+	fake := testFakeSlack(t)
+	fake.setScopes("channels:read", "channels:manage")
+	meta := fake.providerMeta(t)
+	diff := func(config map[string]interface{}) error {
+		config["name"] = "scopes"
+		config["is_private"] = false
+		_, err := resourceSlackConversation().SimpleDiff(context.Background(), nil, terraform.NewResourceConfigRaw(config), meta)
+		return err
+	}
+
+	require.NoError(t, diff(map[string]interface{}{}))
+	require.ErrorContains(t, diff(map[string]interface{}{"ignore_bots": true}),
+		"the Slack token is missing the users:read scope required by slack_conversation")
+	require.ErrorContains(t, diff(map[string]interface{}{"ignore_external_members": true}),
+		"the Slack token is missing the users:read scope required by slack_conversation")
+	require.ErrorContains(t, diff(map[string]interface{}{"permanent_usergroups": []interface{}{"S0ONCALL"}}),
+		"the Slack token is missing the users:read scope required by slack_conversation")
+
+	fake.setScopes("channels:read", "channels:manage", "users:read")
+	meta = fake.providerMeta(t)
+	require.ErrorContains(t, diff(map[string]interface{}{"permanent_usergroups": []interface{}{"S0ONCALL"}}),
+		"the Slack token is missing the usergroups:read scope required by slack_conversation")
+}
+
+func TestConversationMembersDrift(t *testing.T) {
+	fake := testFakeSlack(t)
+	meta := fake.providerMeta(t)
+
+	create := func(action string) *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, resourceSlackConversation().Schema, map[string]interface{}{
+			"name":                               "drift-" + action,
+			"is_private":                         false,
+			"permanent_members":                  []interface{}{testUser00.id},
+			"action_on_update_permanent_members": action,
+		})
+		diags := resourceSlackConversationCreate(context.Background(), d, meta)
+		require.False(t, diags.HasError(), "%v", diags)
+		return d
+	}
+	read := func(d *schema.ResourceData) {
+		diags := resourceSlackConversationRead(context.Background(), d, meta)
+		require.False(t, diags.HasError(), "%v", diags)
+	}
+	attr := func(d *schema.ResourceData, key string) []string {
+		s := schemaSetToSlice(d.Get(key).(*schema.Set))
+		sort.Strings(s)
+		return s
+	}
+
+	t.Run("kick reports the members who joined or left", func(t *testing.T) {
+		d := create(conversationActionOnUpdatePermanentMembersKick)
+		require.Equal(t, []string{testUser00.id}, attr(d, "permanent_members"))
+
+		fake.setMembers(d.Id(), testUserCreator.id, testUser00.id, testUser01.id)
+		read(d)
+		require.Equal(t, sortedStrings(testUser00.id, testUser01.id, testUserCreator.id), attr(d, "members"))
+		require.Equal(t, sortedStrings(testUser00.id, testUser01.id), attr(d, "permanent_members"), "the creator is never kicked")
+
+		fake.setMembers(d.Id(), testUserCreator.id)
+		read(d)
+		require.Equal(t, []string{testUserCreator.id}, attr(d, "members"))
+		require.Empty(t, attr(d, "permanent_members"))
+	})
+
+	t.Run("kick doesn't report the members of unmanaged channels", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourceSlackConversation().Schema, map[string]interface{}{
+			"name":       "drift-unmanaged",
+			"is_private": false,
+		})
+		diags := resourceSlackConversationCreate(context.Background(), d, meta)
+		require.False(t, diags.HasError(), "%v", diags)
+
+		fake.setMembers(d.Id(), testUserCreator.id, testUser00.id, testUser01.id)
+		read(d)
+		require.Equal(t, sortedStrings(testUser00.id, testUser01.id, testUserCreator.id), attr(d, "members"))
+		require.Empty(t, attr(d, "permanent_members"))
+	})
+
+	t.Run("none only reports the members", func(t *testing.T) {
+		d := create(conversationActionOnUpdatePermanentMembersNone)
+
+		fake.setMembers(d.Id(), testUserCreator.id, testUser00.id, testUser01.id)
+		read(d)
+		require.Equal(t, sortedStrings(testUser00.id, testUser01.id, testUserCreator.id), attr(d, "members"))
+		require.Equal(t, []string{testUser00.id}, attr(d, "permanent_members"))
+	})
+}
+
+func TestAccSlackConversationMembersDrift(t *testing.T) {
+	var providers []*schema.Provider
+	name := testAccRandomName(t, conversationNamePrefix)
+	resourceName := fmt.Sprintf("slack_conversation.%s", name)
+	channel := testAccSlackConversationWithMembers(name, []string{testUser00.id})
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(&providers),
+		CheckDestroy:      testAccCheckConversationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSlackConversationConfig(channel),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "members.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "members.*", testUserCreator.id),
+					resource.TestCheckTypeSetElemAttr(resourceName, "members.*", testUser00.id),
+				),
+			},
+			{
+				PreConfig: func() {
+					c, err := testAccProvider.Meta().(*providerMeta).botClient()
+					require.NoError(t, err)
+					id := findConversationID(t, c, name)
+					_, err = c.InviteUsersToConversationContext(context.Background(), id, testUser01.id)
+					require.NoError(t, err)
+				},
+				Config:             testAccSlackConversationConfig(channel),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccSlackConversationConfig(channel),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSlackChannelAttributes(t, resourceName, channel),
+					resource.TestCheckResourceAttr(resourceName, "members.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func findConversationID(t *testing.T, c *apiClient, name string) string {
+	channel, err := findExistingChannel(context.Background(), c, name, true, "")
+	require.NoError(t, err)
+	return channel.ID
+}
+
+func sortedStrings(s ...string) []string {
+	sort.Strings(s)
+	return s
+}
+
+func TestConversationLargeMembership(t *testing.T) {
+	fake := testFakeSlack(t)
+	meta := fake.providerMeta(t)
+
+	users := make([]string, 6000)
+	for i := range users {
+		users[i] = fake.addUser("", fmt.Sprintf("user%d", i), fmt.Sprintf("user%d@example.com", i))
+	}
+	id := fake.addChannel("large-channel", false, users[:5000]...)
+	var permanentMembers []interface{}
+	for _, user := range users[1000:] {
+		permanentMembers = append(permanentMembers, user)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceSlackConversation().Schema, map[string]interface{}{
+		"name":                               "large-channel",
+		"is_private":                         false,
+		"permanent_members":                  permanentMembers,
+		"action_on_update_permanent_members": conversationActionOnUpdatePermanentMembersKick,
+	})
+	d.SetId(id)
+
+	diags := resourceSlackConversationRead(context.Background(), d, meta)
+	require.False(t, diags.HasError(), "%v", diags)
+	require.Equal(t, 5001, d.Get("members").(*schema.Set).Len(), "all the pages of members are read")
+
+	client, err := meta.botClient()
+	require.NoError(t, err)
+	// the read reported the actual members as permanent_members
+	require.NoError(t, d.Set("permanent_members", permanentMembers))
+	require.NoError(t, updateChannelMembers(context.Background(), d, meta, client, id))
+
+	channel, ok := fake.channel(id)
+	require.True(t, ok)
+	require.Equal(t, sortedStrings(append([]string{channel.Creator}, users[1000:]...)...), sortedStrings(channel.Members...))
+	require.Equal(t, 1000, fake.callCount("conversations.kick"))
+	require.Equal(t, 1, fake.callCount("conversations.invite"), "only the 1000 users who aren't members are invited")
+
+	fresh := make([]string, 2500)
+	for i := range fresh {
+		fresh[i] = fake.addUser("", fmt.Sprintf("fresh%d", i), fmt.Sprintf("fresh%d@example.com", i))
+	}
+	require.NoError(t, d.Set("permanent_members", append(users[1000:], fresh...)))
+	require.NoError(t, updateChannelMembers(context.Background(), d, meta, client, id))
+
+	channel, _ = fake.channel(id)
+	require.Len(t, channel.Members, 1+5000+2500)
+	require.Equal(t, 1+3, fake.callCount("conversations.invite"), "invites are sent in batches of 1000")
+}
+
+func TestConversationImport(t *testing.T) {
+	fake := testFakeSlack(t)
+	meta := fake.providerMeta(t)
+	public := fake.addChannel("incident-response", false)
+	private := fake.addChannel("secops", true)
+	fake.addChannel("secops", false)
+
+	importID := func(id string) (*schema.ResourceData, error) {
+		d := resourceSlackConversation().TestResourceData()
+		d.SetId(id)
+		imported, err := resourceSlackConversationImport(context.Background(), d, meta)
+		if err != nil {
+			return nil, err
+		}
+		require.Len(t, imported, 1)
+		return imported[0], nil
+	}
+
+	for id, want := range map[string]string{
+		public:                   public,
+		"name:incident-response": public,
+		"private:name:secops":    private,
+	} {
+		d, err := importID(id)
+		require.NoError(t, err, id)
+		require.Equal(t, want, d.Id(), id)
+		require.Equal(t, conversationActionOnDestroyArchive, d.Get("action_on_destroy"), id)
+		require.Equal(t, conversationActionOnUpdatePermanentMembersKick, d.Get("action_on_update_permanent_members"), id)
+		require.Equal(t, false, d.Get("adopt_existing_channel"), id)
+	}
+
+	_, err := importID("name:unknown")
+	require.EqualError(t, err, "couldn't import conversation name:unknown: could not find channel with name unknown")
+	_, err = importID("private:secops")
+	require.EqualError(t, err, `invalid import ID "private:secops", expected <channel_id>, name:<name> or private:name:<name>`)
+}
+
+func TestAccSlackConversationImportByName(t *testing.T) {
+	var providers []*schema.Provider
+	publicName := testAccRandomName(t, conversationNamePrefix)
+	privateName := testAccRandomName(t, conversationNamePrefix)
+	public := testAccSlackConversationWithMembers(publicName, []string{testUser00.id})
+	public.IsPrivate = false
+	private := testAccSlackConversationWithMembers(privateName, []string{testUser00.id})
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(&providers),
+		CheckDestroy:      testAccCheckConversationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSlackConversationConfig(public) + testAccSlackConversationConfig(private),
+			},
+			{
+				ResourceName:            "slack_conversation." + publicName,
+				ImportState:             true,
+				ImportStateId:           "name:" + publicName,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"permanent_members", "team_id"},
+			},
+			{
+				ResourceName:            "slack_conversation." + privateName,
+				ImportState:             true,
+				ImportStateId:           "private:name:" + privateName,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"permanent_members", "team_id"},
+			},
+		},
+	})
+}
+
+func TestConversationVisibility(t *testing.T) {
+	fake := testFakeSlack(t)
+	id := fake.addChannel("visibility", false)
+
+	state := func(action string) *terraform.InstanceState {
+		return &terraform.InstanceState{
+			ID: id,
+			Attributes: map[string]string{
+				"id":                                 id,
+				"name":                               "visibility",
+				"is_private":                         "false",
+				"is_archived":                        "false",
+				"action_on_destroy":                  action,
+				"action_on_update_permanent_members": conversationActionOnUpdatePermanentMembersKick,
+				"adopt_existing_channel":             "false",
+			},
+		}
+	}
+	diff := func(t *testing.T, meta *providerMeta, action string) (*terraform.InstanceDiff, error) {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":              "visibility",
+			"is_private":        true,
+			"action_on_destroy": action,
+		})
+		return resourceSlackConversation().SimpleDiff(context.Background(), state(action), config, meta)
+	}
+
+	t.Run("converted in place with the admin scopes", func(t *testing.T) {
+		admin := fake.providerMeta(t)
+		d, err := diff(t, admin, conversationActionOnDestroyArchive)
+		require.NoError(t, err)
+		require.Equal(t, "true", d.Attributes["is_private"].New)
+		require.False(t, d.Attributes["is_private"].RequiresNew)
+
+		require.NoError(t, convertConversation(context.Background(), admin, id, true))
+		channel, _ := fake.channel(id)
+		require.True(t, channel.IsPrivate)
+		require.Equal(t, 1, fake.callCount("admin.conversations.convertToPrivate"))
+
+		require.NoError(t, convertConversation(context.Background(), admin, id, false))
+		channel, _ = fake.channel(id)
+		require.False(t, channel.IsPrivate)
+
+		err = convertConversation(context.Background(), admin, id, false)
+		require.EqualError(t, err, fmt.Sprintf("couldn't convert conversation %s with admin.conversations.convertToPublic: channel_already_public", id))
+	})
+
+	t.Run("not replaced when the name stays taken", func(t *testing.T) {
+		meta := fake.providerMetaWithoutAdmin(t)
+		for _, action := range []string{conversationActionOnDestroyArchive, conversationActionOnDestroyNone, conversationActionOnDestroyDelete} {
+			_, err := diff(t, meta, action)
+			require.ErrorContains(t, err, fmt.Sprintf("is_private of conversation %s can't be changed in place without the admin.conversations:write scope", id))
+		}
+	})
+
+	t.Run("replaced without the admin scopes", func(t *testing.T) {
+		meta := fake.providerMetaWithoutAdmin(t)
+		d, err := diff(t, meta, conversationActionOnDestroyRenameArchive)
+		require.NoError(t, err)
+		require.True(t, d.Attributes["is_private"].RequiresNew)
+
+		// Terraform destroys the old channel, then creates the new one
+		r := resourceSlackConversation()
+		_, diags := r.Apply(context.Background(), state(conversationActionOnDestroyRenameArchive), &terraform.InstanceDiff{Destroy: true}, meta)
+		require.False(t, diags.HasError(), "%v", diags)
+		d, err = r.SimpleDiff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":              "visibility",
+			"is_private":        true,
+			"action_on_destroy": conversationActionOnDestroyRenameArchive,
+		}), meta)
+		require.NoError(t, err)
+		newState, diags := r.Apply(context.Background(), nil, d, meta)
+		require.False(t, diags.HasError(), "%v", diags)
+		require.NotEqual(t, id, newState.ID)
+		require.Equal(t, "true", newState.Attributes["is_private"])
+
+		old, _ := fake.channel(id)
+		require.True(t, old.IsArchived)
+		require.Regexp(t, `^visibility-\d{17}$`, old.Name)
+		channel, ok := fake.channel(newState.ID)
+		require.True(t, ok)
+		require.Equal(t, "visibility", channel.Name)
+		require.True(t, channel.IsPrivate)
+	})
+}
+
+func TestArchivedConversationName(t *testing.T) {
+	now := time.Date(2026, 10, 17, 18, 34, 21, 42*int(time.Millisecond), time.UTC)
+	require.Equal(t, "incidents-20261017183421042", archivedConversationName("incidents", now))
+
+	name := archivedConversationName(strings.Repeat("a", conversationNameMaxLength), now)
+	require.Len(t, name, conversationNameMaxLength)
+	require.True(t, strings.HasSuffix(name, "-20261017183421042"))
+
+	name = archivedConversationName(strings.Repeat("é", conversationNameMaxLength), now)
+	require.True(t, utf8.ValidString(name))
+	require.Equal(t, conversationNameMaxLength, utf8.RuneCountInString(name))
+	require.Equal(t, strings.Repeat("é", conversationNameMaxLength-len("-20261017183421042"))+"-20261017183421042", name)
+}
+
+func TestConversationActionOnDestroy(t *testing.T) {
+	fake := testFakeSlack(t)
+
+	destroy := func(t *testing.T, meta *providerMeta, action string) (string, diag.Diagnostics) {
+		d := schema.TestResourceDataRaw(t, resourceSlackConversation().Schema, map[string]interface{}{
+			"name":              "destroyed",
+			"is_private":        false,
+			"action_on_destroy": action,
+		})
+		diags := resourceSlackConversationCreate(context.Background(), d, meta)
+		require.False(t, diags.HasError(), "%v", diags)
+		return d.Id(), resourceSlackConversationDelete(context.Background(), d, meta)
+	}
+
+	t.Run("rename_archive frees the name", func(t *testing.T) {
+		id, diags := destroy(t, fake.providerMeta(t), conversationActionOnDestroyRenameArchive)
+		require.Empty(t, diags)
+
+		channel, ok := fake.channel(id)
+		require.True(t, ok)
+		require.True(t, channel.IsArchived)
+		require.Regexp(t, `^destroyed-\d{17}$`, channel.Name)
+	})
+
+	t.Run("delete uses the admin APIs", func(t *testing.T) {
+		id, diags := destroy(t, fake.providerMeta(t), conversationActionOnDestroyDelete)
+		require.Empty(t, diags)
+
+		_, ok := fake.channel(id)
+		require.False(t, ok)
+	})
+
+	t.Run("delete archives without the admin scopes", func(t *testing.T) {
+		id, diags := destroy(t, fake.providerMetaWithoutAdmin(t), conversationActionOnDestroyDelete)
+		require.Len(t, diags, 1)
+		require.Equal(t, diag.Warning, diags[0].Severity)
+		require.Equal(t, fmt.Sprintf("conversation %s (destroyed) is archived instead of deleted", id), diags[0].Summary)
+
+		channel, ok := fake.channel(id)
+		require.True(t, ok)
+		require.True(t, channel.IsArchived)
+	})
+}
+
+func TestAccSlackConversationRenameArchive(t *testing.T) {
+	var providers []*schema.Provider
+	name := testAccRandomName(t, conversationNamePrefix)
+	resourceName := fmt.Sprintf("slack_conversation.%s", name)
+	config := `
+resource slack_conversation %s {
+  name              = "%s"
+  is_private        = %t
+  action_on_destroy = "rename_archive"
+}
+`
+	var id string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(&providers),
+		CheckDestroy:      testAccCheckConversationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(config, name, name, false),
+				Check: func(s *terraform.State) error {
+					id = s.RootModule().Resources[resourceName].Primary.ID
+					return nil
+				},
+			},
+			{
+				// without the admin scopes is_private can't be changed in
+				// place, so the channel is renamed and archived, and its name
+				// reused.
+				Config: fmt.Sprintf(config, name, name, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", name),
+					resource.TestCheckResourceAttr(resourceName, "is_private", "true"),
+					func(s *terraform.State) error {
+						replaced := s.RootModule().Resources[resourceName].Primary.ID != id
+						if testAccProvider.Meta().(*providerMeta).hasAdminAPIs() {
+							if replaced {
+								return fmt.Errorf("conversation %s was replaced instead of converted", id)
+							}
+						} else if !replaced {
+							return fmt.Errorf("conversation %s was not replaced", id)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestConversationMembersTimeout(t *testing.T) {
+	fake := testFakeSlack(t)
+	meta := fake.providerMeta(t)
+	client, err := meta.botClient()
+	require.NoError(t, err)
+	id := fake.addChannel("slow-channel", false, testUser00.id, testUser01.id)
+
+	// waiting for the rate limit would outlast the timeout
+	fake.rateLimitFor("conversations.kick", 1, 60)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	start := time.Now()
+	err = setConversationMembers(ctx, client, id, nil, true, ignoredMembers{})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, fmt.Sprintf("timed out after kicking 0 of 2 members from conversation %s", id))
+	require.Less(t, time.Since(start), 5*time.Second, "gives up without waiting for the deadline")
+
+	// the next apply picks up where it stopped
+	require.NoError(t, setConversationMembers(context.Background(), client, id, nil, true, ignoredMembers{}))
+	channel, _ := fake.channel(id)
+	require.Equal(t, []string{testUserCreator.id}, channel.Members)
+}
@@ -0,0 +1,284 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/slack-go/slack"
+)
+
+func resourceSlackPinnedMessage() *schema.Resource {
+	return &schema.Resource{
+		ReadContext:   resourceSlackPinnedMessageRead,
+		CreateContext: resourceSlackPinnedMessageCreate,
+		UpdateContext: resourceSlackPinnedMessageUpdate,
+		DeleteContext: resourceSlackPinnedMessageDelete,
+		CustomizeDiff: customdiff.All(
+			customizeDiffPinnedMessageScopes,
+			customizeDiffPinnedMessage,
+		),
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSlackPinnedMessageImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"channel_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"timestamp": {
+				Type:          schema.TypeString,
+				Description:   "The timestamp of an existing message to pin. Computed when the message is posted by the provider",
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"text", "blocks"},
+			},
+			"text": {
+				Type:         schema.TypeString,
+				Description:  "The text of the message to post and pin, or its fallback text when blocks is set",
+				Optional:     true,
+				AtLeastOneOf: []string{"timestamp", "text", "blocks"},
+			},
+			"blocks": {
+				Type:         schema.TypeString,
+				Description:  "The Block Kit blocks of the message to post and pin, as a JSON array",
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"delete_message_on_destroy": {
+				Type:        schema.TypeBool,
+				Description: "Delete the message posted by the provider on destroy, on top of unpinning it",
+				Optional:    true,
+				Default:     false,
+			},
+			"pinned": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// customizeDiffPinnedMessageScopes checks the scopes of the bot token. The
+// history scopes depend on the type of the channel, which isn't known here.
+func customizeDiffPinnedMessageScopes(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return err
+	}
+	if err := client.requireScopes("slack_pinned_message", "pins:read", "pins:write"); err != nil {
+		return err
+	}
+	if d.Get("text").(string) != "" || d.Get("blocks").(string) != "" {
+		return client.requireScopes("slack_pinned_message", "chat:write")
+	}
+	return nil
+}
+
+// customizeDiffPinnedMessage plans to pin again the messages that were
+// unpinned outside of Terraform.
+func customizeDiffPinnedMessage(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || d.Get("pinned").(bool) {
+		return nil
+	}
+	return d.SetNew("pinned", true)
+}
+
+// resourceSlackPinnedMessageImport accepts <channel_id>/<timestamp>. The
+// message is imported as an existing one, which isn't deleted on destroy.
+func resourceSlackPinnedMessageImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	if _, _, err := parseChannelScopedID(d.Id(), "timestamp"); err != nil {
+		return nil, err
+	}
+	if err := d.Set("delete_message_on_destroy", false); err != nil {
+		return nil, fmt.Errorf("error setting delete_message_on_destroy: %w", err)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceSlackPinnedMessageCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	channelID := d.Get("channel_id").(string)
+	timestamp := d.Get("timestamp").(string)
+	if timestamp == "" {
+		var resp struct {
+			Timestamp string `json:"ts"`
+		}
+		if _, err := client.call(ctx, "chat.postMessage", pinnedMessageValues(d, url.Values{"channel": {channelID}}), &resp); err != nil {
+			return diag.Errorf("couldn't post message to conversation %s: %s", channelID, err)
+		}
+		timestamp = resp.Timestamp
+	}
+
+	// the message is in the state as soon as it's posted, so that a failure
+	// to pin it doesn't post it again
+	d.SetId(pinnedMessageID(channelID, timestamp))
+	if err := d.Set("timestamp", timestamp); err != nil {
+		return diag.Errorf("error setting timestamp: %s", err)
+	}
+	if err := pinMessage(ctx, client, channelID, timestamp); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceSlackPinnedMessageRead(ctx, d, m)
+}
+
+func resourceSlackPinnedMessageRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id := d.Id()
+	channelID, timestamp, err := parseChannelScopedID(id, "timestamp")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	items, _, err := client.ListPinsContext(ctx, channelID)
+	if err != nil {
+		if err.Error() == "channel_not_found" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("channel with ID %s not found, removing from state", channelID),
+			})
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("couldn't list the pins of conversation %s: %s", channelID, err)
+	}
+
+	pinned := false
+	for _, item := range items {
+		if item.Message != nil && item.Message.Timestamp == timestamp {
+			pinned = true
+			break
+		}
+	}
+
+	// pins.list doesn't tell unpinned messages from deleted ones
+	if !pinned {
+		history, err := client.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+			ChannelID: channelID,
+			Latest:    timestamp,
+			Oldest:    timestamp,
+			Inclusive: true,
+			Limit:     1,
+		})
+		if err != nil {
+			return diag.Errorf("couldn't get message %s of conversation %s: %s", timestamp, channelID, err)
+		}
+		if len(history.Messages) == 0 || history.Messages[0].Timestamp != timestamp {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("message %s not found in channel %s, removing from state", timestamp, channelID),
+			})
+			d.SetId("")
+			return diags
+		}
+	}
+
+	if err := d.Set("channel_id", channelID); err != nil {
+		return diag.Errorf("error setting channel_id: %s", err)
+	}
+	if err := d.Set("timestamp", timestamp); err != nil {
+		return diag.Errorf("error setting timestamp: %s", err)
+	}
+	if err := d.Set("pinned", pinned); err != nil {
+		return diag.Errorf("error setting pinned: %s", err)
+	}
+	return diags
+}
+
+func resourceSlackPinnedMessageUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	channelID := d.Get("channel_id").(string)
+	timestamp := d.Get("timestamp").(string)
+	if d.HasChanges("text", "blocks") {
+		values := pinnedMessageValues(d, url.Values{"channel": {channelID}, "ts": {timestamp}})
+		if values.Get("blocks") == "" {
+			// removes the blocks of the message
+			values.Set("blocks", "[]")
+		}
+		if _, err := client.call(ctx, "chat.update", values, nil); err != nil {
+			return diag.Errorf("couldn't update message %s of conversation %s: %s", timestamp, channelID, err)
+		}
+	}
+	if d.HasChange("pinned") {
+		if err := pinMessage(ctx, client, channelID, timestamp); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceSlackPinnedMessageRead(ctx, d, m)
+}
+
+func resourceSlackPinnedMessageDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client, err := m.(*providerMeta).botClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	channelID := d.Get("channel_id").(string)
+	timestamp := d.Get("timestamp").(string)
+	if err := client.RemovePinContext(ctx, channelID, slack.NewRefToMessage(channelID, timestamp)); err != nil {
+		switch err.Error() {
+		case "no_pin", "message_not_found", "channel_not_found":
+		default:
+			return diag.Errorf("couldn't unpin message %s of conversation %s: %s", timestamp, channelID, err)
+		}
+	}
+
+	// only the messages posted by the provider are deleted
+	posted := d.Get("text").(string) != "" || d.Get("blocks").(string) != ""
+	if posted && d.Get("delete_message_on_destroy").(bool) {
+		if _, _, err := client.DeleteMessageContext(ctx, channelID, timestamp); err != nil {
+			switch err.Error() {
+			case "message_not_found", "channel_not_found":
+			default:
+				return diag.Errorf("couldn't delete message %s of conversation %s: %s", timestamp, channelID, err)
+			}
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func pinMessage(ctx context.Context, client *apiClient, channelID, timestamp string) error {
+	if err := client.AddPinContext(ctx, channelID, slack.NewRefToMessage(channelID, timestamp)); err != nil {
+		if err.Error() != "already_pinned" {
+			return fmt.Errorf("couldn't pin message %s of conversation %s: %w", timestamp, channelID, err)
+		}
+	}
+	return nil
+}
+
+// pinnedMessageValues adds the content of the message to values. The blocks
+// are sent as configured, as slack-go only knows some of the block types.
+func pinnedMessageValues(d *schema.ResourceData, values url.Values) url.Values {
+	values.Set("text", d.Get("text").(string))
+	if blocks := d.Get("blocks").(string); blocks != "" {
+		values.Set("blocks", blocks)
+	}
+	return values
+}
+
+func pinnedMessageID(channelID, timestamp string) string {
+	return channelID + "/" + timestamp
+}
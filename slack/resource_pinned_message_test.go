@@ -0,0 +1,153 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPinnedMessageScopes(t *testing.T) {
+	fake := testFakeSlack(t)
+	fake.setScopes("pins:read", "pins:write")
+	meta := fake.providerMeta(t)
+	diff := func(config map[string]interface{}) error {
+		config["channel_id"] = "C0123456789"
+		_, err := resourceSlackPinnedMessage().SimpleDiff(context.Background(), nil, terraform.NewResourceConfigRaw(config), meta)
+		return err
+	}
+
+	require.NoError(t, diff(map[string]interface{}{"timestamp": "1690000000.000100"}), "existing messages aren't posted")
+	require.ErrorContains(t, diff(map[string]interface{}{"text": "Read the runbook"}),
+		"the Slack token is missing the chat:write scope required by slack_pinned_message")
+}
+
+func TestAccSlackPinnedMessage(t *testing.T) {
+	var providers []*schema.Provider
+	channel := createTestConversation(t)
+	posted := "slack_pinned_message.posted"
+	existing := "slack_pinned_message.existing"
+
+	client, err := sharedSlackClient()
+	require.NoError(t, err)
+	_, existingTimestamp, err := client.(*slack.Client).PostMessageContext(context.Background(), channel.ID, slack.MsgOptionText("Existing instructions", false))
+	require.NoError(t, err)
+
+	var timestamp string
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(&providers),
+		CheckDestroy:      testAccCheckPinnedMessageDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSlackPinnedMessageConfig(channel.ID, "Read the runbook first", existingTimestamp),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(posted, "channel_id", channel.ID),
+					resource.TestCheckResourceAttr(posted, "pinned", "true"),
+					resource.TestCheckResourceAttrSet(posted, "timestamp"),
+					resource.TestCheckResourceAttr(existing, "id", pinnedMessageID(channel.ID, existingTimestamp)),
+					resource.TestCheckResourceAttr(existing, "pinned", "true"),
+					func(s *terraform.State) error {
+						timestamp = s.RootModule().Resources[posted].Primary.Attributes["timestamp"]
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccSlackPinnedMessageConfig(channel.ID, "Read the runbook and the dashboard first", existingTimestamp),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(posted, "text", "Read the runbook and the dashboard first"),
+					func(s *terraform.State) error {
+						if ts := s.RootModule().Resources[posted].Primary.Attributes["timestamp"]; ts != timestamp {
+							return fmt.Errorf("message %s was posted again as %s instead of updated", timestamp, ts)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// unpinned in the UI
+				PreConfig: func() {
+					client, err := testAccProvider.Meta().(*providerMeta).botClient()
+					require.NoError(t, err)
+					require.NoError(t, client.RemovePinContext(context.Background(), channel.ID, slack.NewRefToMessage(channel.ID, timestamp)))
+				},
+				Config: testAccSlackPinnedMessageConfig(channel.ID, "Read the runbook and the dashboard first", existingTimestamp),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(posted, "pinned", "true"),
+					func(s *terraform.State) error {
+						if ts := s.RootModule().Resources[posted].Primary.Attributes["timestamp"]; ts != timestamp {
+							return fmt.Errorf("message %s was posted again as %s instead of pinned again", timestamp, ts)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      existing,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccSlackPinnedMessageConfig(channelID, text, timestamp string) string {
+	return fmt.Sprintf(`
+resource slack_pinned_message posted {
+  channel_id                = "%s"
+  text                      = "%s"
+  blocks                    = jsonencode([{ type = "section", text = { type = "mrkdwn", text = "*%s*" } }])
+  delete_message_on_destroy = true
+}
+
+resource slack_pinned_message existing {
+  channel_id = "%s"
+  timestamp  = "%s"
+}
+`, channelID, text, text, channelID, timestamp)
+}
+
+func testAccCheckPinnedMessageDestroy(s *terraform.State) error {
+	client, err := testAccProvider.Meta().(*providerMeta).botClient()
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "slack_pinned_message" {
+			continue
+		}
+
+		channelID := rs.Primary.Attributes["channel_id"]
+		items, _, err := client.ListPinsContext(context.Background(), channelID)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if item.Message != nil && item.Message.Timestamp == rs.Primary.Attributes["timestamp"] {
+				return fmt.Errorf("message %s is still pinned", rs.Primary.ID)
+			}
+		}
+
+		history, err := client.GetConversationHistoryContext(context.Background(), &slack.GetConversationHistoryParameters{
+			ChannelID: channelID,
+			Latest:    rs.Primary.Attributes["timestamp"],
+			Oldest:    rs.Primary.Attributes["timestamp"],
+			Inclusive: true,
+			Limit:     1,
+		})
+		if err != nil {
+			return err
+		}
+		deleted := len(history.Messages) == 0
+		if wantDeleted := rs.Primary.Attributes["delete_message_on_destroy"] == "true"; deleted != wantDeleted {
+			return fmt.Errorf("message %s: deleted is %t, expected %t", rs.Primary.ID, deleted, wantDeleted)
+		}
+	}
+	return nil
+}
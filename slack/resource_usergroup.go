@@ -3,6 +3,8 @@ package slack
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -16,6 +18,14 @@ func resourceSlackUserGroup() *schema.Resource {
 		CreateContext: resourceSlackUserGroupCreate,
 		UpdateContext: resourceSlackUserGroupUpdate,
 		DeleteContext: resourceSlackUserGroupDelete,
+		Timeouts:      userGroupTimeouts(),
+		CustomizeDiff: func(_ context.Context, _ *schema.ResourceDiff, m interface{}) error {
+			client, err := m.(*providerMeta).userClient()
+			if err != nil {
+				return err
+			}
+			return client.requireScopes("slack_usergroup", "usergroups:read", "usergroups:write")
+		},
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -50,12 +60,21 @@ func resourceSlackUserGroup() *schema.Resource {
 				Set:      schema.HashString,
 				Optional: true,
 			},
+			"team_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
 		},
 	}
 }
 
 func resourceSlackUserGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*slack.Client)
+	client, err := m.(*providerMeta).userClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -63,30 +82,34 @@ func resourceSlackUserGroupCreate(ctx context.Context, d *schema.ResourceData, m
 	channels := d.Get("channels").(*schema.Set)
 	users := d.Get("users").(*schema.Set)
 
-	userGroup := slack.UserGroup{
-		Name:        name,
-		Description: description,
-		Handle:      handle,
-		Prefs: slack.UserGroupPrefs{
-			Channels: schemaSetToSlice(channels),
-		},
+	teamID := m.(*providerMeta).resourceTeamID(d)
+
+	values := url.Values{"name": {name}}
+	if handle != "" {
+		values.Set("handle", handle)
 	}
-	createdUserGroup, err := client.CreateUserGroupContext(ctx, userGroup)
+	if description != "" {
+		values.Set("description", description)
+	}
+	if channels.Len() > 0 {
+		values.Set("channels", strings.Join(schemaSetToSlice(channels), ","))
+	}
+	createdUserGroup, err := callUserGroup(ctx, client, "usergroups.create", teamID, values)
 	if err != nil {
 		if err.Error() != "name_already_exists" && err.Error() != "handle_already_exists" {
 			return diag.Errorf("could not create usergroup %s: %s", name, err)
 		}
-		group, err := findUserGroupByName(ctx, name, true, m)
+		group, err := findUserGroupByName(ctx, name, true, client, teamID)
 		if err != nil {
 			return diag.Errorf("could not find usergroup %s: %s", name, err)
 		}
-		_, err = client.EnableUserGroupContext(ctx, group.ID)
+		_, err = callUserGroup(ctx, client, "usergroups.enable", teamID, url.Values{"usergroup": {group.ID}})
 		if err != nil {
 			if err.Error() != "already_enabled" {
 				return diag.Errorf("could not enable usergroup %s (%s): %s", name, group.ID, err)
 			}
 		}
-		_, err = client.UpdateUserGroupContext(ctx, group.ID)
+		_, err = callUserGroup(ctx, client, "usergroups.update", teamID, url.Values{"usergroup": {group.ID}})
 		if err != nil {
 			return diag.Errorf("could not update usergroup %s (%s): %s", name, group.ID, err)
 		}
@@ -96,8 +119,12 @@ func resourceSlackUserGroupCreate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	if users.Len() > 0 {
-		_, err := client.UpdateUserGroupMembersContext(ctx, d.Id(), strings.Join(schemaSetToSlice(users), ","))
+		_, err := callUserGroup(ctx, client, "usergroups.users.update", teamID, url.Values{
+			"usergroup": {d.Id()},
+			"users":     {strings.Join(schemaSetToSlice(users), ",")},
+		})
 		if err != nil {
+			err = withProgress(ctx, err, "creating usergroup %s (%s)", name, d.Id())
 			return diag.Errorf("could not update usergroup members %s: %s", name, err)
 		}
 		schemaSetToSlice(users)
@@ -106,10 +133,13 @@ func resourceSlackUserGroupCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceSlackUserGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*slack.Client)
+	client, err := m.(*providerMeta).userClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	id := d.Id()
 	var diags diag.Diagnostics
-	userGroups, err := client.GetUserGroupsContext(ctx, slack.GetUserGroupsOptionIncludeUsers(true))
+	userGroups, err := listUserGroups(ctx, client, m.(*providerMeta).resourceTeamID(d), false)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("couldn't get usergroups: %w", err))
 	}
@@ -127,9 +157,8 @@ func resourceSlackUserGroupRead(ctx context.Context, d *schema.ResourceData, m i
 	return diags
 }
 
-func findUserGroupByName(ctx context.Context, name string, includeDisabled bool, m interface{}) (slack.UserGroup, error) {
-	client := m.(*slack.Client)
-	userGroups, err := client.GetUserGroupsContext(ctx, slack.GetUserGroupsOptionIncludeDisabled(includeDisabled), slack.GetUserGroupsOptionIncludeUsers(true))
+func findUserGroupByName(ctx context.Context, name string, includeDisabled bool, client *apiClient, teamID string) (slack.UserGroup, error) {
+	userGroups, err := listUserGroups(ctx, client, teamID, includeDisabled)
 	if err != nil {
 		return slack.UserGroup{}, err
 	}
@@ -143,9 +172,8 @@ func findUserGroupByName(ctx context.Context, name string, includeDisabled bool,
 	return slack.UserGroup{}, fmt.Errorf("could not find usergroup %s", name)
 }
 
-func findUserGroupByID(ctx context.Context, id string, includeDisabled bool, m interface{}) (slack.UserGroup, error) {
-	client := m.(*slack.Client)
-	userGroups, err := client.GetUserGroupsContext(ctx, slack.GetUserGroupsOptionIncludeDisabled(includeDisabled), slack.GetUserGroupsOptionIncludeUsers(true))
+func findUserGroupByID(ctx context.Context, id string, includeDisabled bool, client *apiClient, teamID string) (slack.UserGroup, error) {
+	userGroups, err := listUserGroups(ctx, client, teamID, includeDisabled)
 	if err != nil {
 		return slack.UserGroup{}, err
 	}
@@ -159,8 +187,48 @@ func findUserGroupByID(ctx context.Context, id string, includeDisabled bool, m i
 	return slack.UserGroup{}, fmt.Errorf("could not find usergroup %s", id)
 }
 
+// listUserGroups returns the usergroups with their users. teamID selects the
+// workspace when using an org-level token; slack-go can't send it, so the
+// usergroups.* methods are called directly.
+func listUserGroups(ctx context.Context, client *apiClient, teamID string, includeDisabled bool) ([]slack.UserGroup, error) {
+	values := url.Values{
+		"include_users":    {"true"},
+		"include_disabled": {strconv.FormatBool(includeDisabled)},
+	}
+	if teamID != "" {
+		values.Set("team_id", teamID)
+	}
+
+	var resp struct {
+		UserGroups []slack.UserGroup `json:"usergroups"`
+	}
+	if _, err := client.call(ctx, "usergroups.list", values, &resp); err != nil {
+		return nil, err
+	}
+	return resp.UserGroups, nil
+}
+
+// callUserGroup calls one of the usergroups.* methods that return the
+// usergroup they change.
+func callUserGroup(ctx context.Context, client *apiClient, method, teamID string, values url.Values) (slack.UserGroup, error) {
+	if teamID != "" {
+		values.Set("team_id", teamID)
+	}
+
+	var resp struct {
+		UserGroup slack.UserGroup `json:"usergroup"`
+	}
+	if _, err := client.call(ctx, method, values, &resp); err != nil {
+		return slack.UserGroup{}, err
+	}
+	return resp.UserGroup, nil
+}
+
 func resourceSlackUserGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*slack.Client)
+	client, err := m.(*providerMeta).userClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	id := d.Id()
 	name := d.Get("name").(string)
@@ -169,20 +237,29 @@ func resourceSlackUserGroupUpdate(ctx context.Context, d *schema.ResourceData, m
 	channels := d.Get("channels").(*schema.Set)
 	users := d.Get("users").(*schema.Set)
 
-	updateUserGroupOptions := []slack.UpdateUserGroupsOption{
-		slack.UpdateUserGroupsOptionName(name),
-		slack.UpdateUserGroupsOptionChannels(schemaSetToSlice(channels)),
-		slack.UpdateUserGroupsOptionDescription(&description),
-		slack.UpdateUserGroupsOptionHandle(handle),
+	teamID := m.(*providerMeta).resourceTeamID(d)
+
+	values := url.Values{
+		"usergroup":   {id},
+		"name":        {name},
+		"channels":    {strings.Join(schemaSetToSlice(channels), ",")},
+		"description": {description},
 	}
-	_, err := client.UpdateUserGroupContext(ctx, id, updateUserGroupOptions...)
+	if handle != "" {
+		values.Set("handle", handle)
+	}
+	_, err = callUserGroup(ctx, client, "usergroups.update", teamID, values)
 	if err != nil {
 		return diag.Errorf("could not update usergroup %s: %s", name, err)
 	}
 
 	if d.HasChanges("users") {
-		_, err := client.UpdateUserGroupMembersContext(ctx, id, strings.Join(schemaSetToSlice(users), ","))
+		_, err := callUserGroup(ctx, client, "usergroups.users.update", teamID, url.Values{
+			"usergroup": {id},
+			"users":     {strings.Join(schemaSetToSlice(users), ",")},
+		})
 		if err != nil {
+			err = withProgress(ctx, err, "updating usergroup %s (%s) but not its members", name, id)
 			return diag.Errorf("could not update usergroup members %s: %s", name, err)
 		}
 		schemaSetToSlice(users)
@@ -192,10 +269,13 @@ func resourceSlackUserGroupUpdate(ctx context.Context, d *schema.ResourceData, m
 
 func resourceSlackUserGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := m.(*slack.Client)
+	client, err := m.(*providerMeta).userClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	id := d.Id()
-	_, err := client.DisableUserGroupContext(ctx, id)
+	_, err = callUserGroup(ctx, client, "usergroups.disable", m.(*providerMeta).resourceTeamID(d), url.Values{"usergroup": {id}})
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -229,5 +309,9 @@ func updateUserGroupData(d *schema.ResourceData, userGroup slack.UserGroup) diag
 		return diag.Errorf("error setting users: %s", err)
 	}
 
+	if err := d.Set("team_id", userGroup.TeamID); err != nil {
+		return diag.Errorf("error setting team_id: %s", err)
+	}
+
 	return nil
 }
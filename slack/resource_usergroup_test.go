@@ -10,7 +10,6 @@ import (
 	"testing"
 
 	"github.com/hashicorp/go-multierror"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -61,17 +60,17 @@ func TestAccSlackUserGroupTest(t *testing.T) {
 	resourceName := "slack_usergroup.test"
 
 	t.Run("update name, description and handle", func(t *testing.T) {
-		name := acctest.RandomWithPrefix(userGroupResourceNamePrefix)
+		name := testAccRandomName(t, userGroupResourceNamePrefix)
 		createUserGroup := testAccSlackUserGroup(name)
 
-		updateName := acctest.RandomWithPrefix(userGroupResourceNamePrefix)
+		updateName := testAccRandomName(t, userGroupResourceNamePrefix)
 		updateUserGroup := testAccSlackUserGroup(updateName)
 
 		testSlackUserGroupUpdate(t, resourceName, createUserGroup, &updateUserGroup)
 	})
 
 	t.Run("update users", func(t *testing.T) {
-		name := acctest.RandomWithPrefix(userGroupResourceNamePrefix)
+		name := testAccRandomName(t, userGroupResourceNamePrefix)
 		createUserGroup := testAccSlackUserGroupWithUsers(name, []string{}, []string{testUser00.id, testUser01.id})
 
 		updateUserGroup := createUserGroup
@@ -83,7 +82,7 @@ func TestAccSlackUserGroupTest(t *testing.T) {
 	t.Run("update channels", func(t *testing.T) {
 		channel := createTestConversation(t)
 
-		name := acctest.RandomWithPrefix(userGroupResourceNamePrefix)
+		name := testAccRandomName(t, userGroupResourceNamePrefix)
 		createUserGroup := testAccSlackUserGroupWithUsers(name, []string{}, []string{})
 
 		updateUserGroup := createUserGroup
@@ -94,13 +93,14 @@ func TestAccSlackUserGroupTest(t *testing.T) {
 }
 
 func createTestConversation(t *testing.T) *slack.Channel {
+	testAccCassette(t)
 	client, err := sharedSlackClient()
 	if err != nil {
 		require.NoError(t, err, "error getting client: %s", err)
 	}
 
 	c := client.(*slack.Client)
-	channelName := acctest.RandomWithPrefix(conversationNamePrefix)
+	channelName := testAccRandomName(t, conversationNamePrefix)
 
 	channel, err := c.CreateConversationContext(context.Background(), slack.CreateConversationParams{
 		ChannelName: channelName,
@@ -170,8 +170,12 @@ func testCheckSlackUserGroupAttributes(t *testing.T, resourceName string, expect
 			return fmt.Errorf("not found: %s", resourceName)
 		}
 
+		c, err := testAccProvider.Meta().(*providerMeta).userClient()
+		if err != nil {
+			return err
+		}
 		primary := rs.Primary
-		group, err := findUserGroupByID(context.Background(), primary.ID, false, testAccProvider.Meta())
+		group, err := findUserGroupByID(context.Background(), primary.ID, false, c, primary.Attributes["team_id"])
 		if err != nil {
 			return fmt.Errorf("couldn't get conversation info for %s: %s", primary.ID, err)
 		}
@@ -221,7 +225,10 @@ func testAccSlackUserGroupWithUsers(name string, channels, users []string) slack
 }
 
 func testAccCheckUserGroupDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*slack.Client)
+	c, err := testAccProvider.Meta().(*providerMeta).userClient()
+	if err != nil {
+		return err
+	}
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "slack_usergroup" {
 			continue
@@ -255,3 +262,32 @@ resource slack_usergroup test {
 }
 `, c.Name, c.Description, c.Handle, strings.Join(users, ","), strings.Join(channels, ","))
 }
+
+func TestUserGroupTeamID(t *testing.T) {
+	fake := testFakeSlack(t)
+	meta := fake.providerMeta(t)
+	meta.teamID = "T0GRIDTEAM"
+	client, err := meta.userClient()
+	require.NoError(t, err)
+
+	create := func(raw map[string]interface{}) *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, resourceSlackUserGroup().Schema, raw)
+		diags := resourceSlackUserGroupCreate(context.Background(), d, meta)
+		require.False(t, diags.HasError(), "%v", diags)
+		return d
+	}
+
+	provider := create(map[string]interface{}{"name": "grid-usergroup"})
+	require.Equal(t, "T0GRIDTEAM", provider.Get("team_id"), "defaults to the team_id of the provider")
+
+	resource := create(map[string]interface{}{"name": "grid-usergroup", "team_id": "T0OTHERTEAM"})
+	require.Equal(t, "T0OTHERTEAM", resource.Get("team_id"))
+	require.NotEqual(t, provider.Id(), resource.Id(), "the name is only unique within a team")
+
+	group, err := findUserGroupByName(context.Background(), "grid-usergroup", false, client, "T0OTHERTEAM")
+	require.NoError(t, err)
+	require.Equal(t, resource.Id(), group.ID)
+
+	_, err = findUserGroupByName(context.Background(), "grid-usergroup", false, client, fakeSlackTeamID)
+	require.EqualError(t, err, "could not find usergroup grid-usergroup")
+}
@@ -0,0 +1,225 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultMaxRetries = 5
+	defaultMaxBackoff = 30 * time.Second
+	minBackoff        = time.Second
+)
+
+// slackRetryableErrors are the Slack error codes that indicate a transient
+// failure on Slack's side. The call can safely be repeated.
+// See https://api.slack.com/web#errors
+var slackRetryableErrors = []string{
+	"internal_error",
+	"fatal_error",
+	"service_unavailable",
+}
+
+// retryTransport is an http.RoundTripper that retries Slack Web API calls
+// which were rate limited or failed with a transient error. Rate limited calls
+// are retried after the delay requested by Slack in the Retry-After header,
+// everything else is retried with an exponential backoff.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int, maxBackoff time.Duration) *retryTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		minBackoff: minBackoff,
+		maxBackoff: maxBackoff,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	method := path.Base(req.URL.Path)
+
+	for attempt := 0; ; attempt++ {
+		r, err := rewindRequest(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.next.RoundTrip(r)
+		wait, reason := t.retryDelay(ctx, method, resp, err, attempt)
+		if reason == "" || attempt >= t.maxRetries || !canRewindRequest(req) {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		// waiting past the deadline would only delay the same error
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return nil, fmt.Errorf("not retrying %s (%s) in %s, after the deadline: %w", method, reason, wait, context.DeadlineExceeded)
+		}
+
+		tflog.Warn(ctx, "retrying slack api call", map[string]interface{}{
+			"method":  method,
+			"reason":  reason,
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		})
+		if err := sleepWithContext(ctx, wait); err != nil {
+			return nil, fmt.Errorf("canceled while waiting to retry %s: %w", method, err)
+		}
+	}
+}
+
+// retryDelay returns how long to wait before the next attempt, and a short
+// reason describing why the call is retried. An empty reason means the call
+// must not be retried. Rate limited calls weren't processed by Slack, so they
+// are always retried. Calls that failed otherwise may have been processed,
+// so they are only retried when they read data, or when they weren't sent.
+func (t *retryTransport) retryDelay(ctx context.Context, method string, resp *http.Response, err error, attempt int) (time.Duration, string) {
+	if err != nil {
+		if ctx.Err() != nil || !isTemporaryNetworkError(err) || !(isReadMethod(method) || isNotSentError(err)) {
+			return 0, ""
+		}
+		return t.backoff(attempt), err.Error()
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if retryAfter, err := strconv.ParseInt(resp.Header.Get("Retry-After"), 10, 64); err == nil {
+			return time.Duration(retryAfter) * time.Second, "rate_limited"
+		}
+		return t.backoff(attempt), "rate_limited"
+	}
+
+	if !isReadMethod(method) {
+		return 0, ""
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return t.backoff(attempt), resp.Status
+	}
+
+	if code := slackErrorCode(resp); contains(slackRetryableErrors, code) {
+		return t.backoff(attempt), code
+	}
+
+	return 0, ""
+}
+
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	wait := t.minBackoff
+	for i := 0; i < attempt && wait < t.maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > t.maxBackoff {
+		return t.maxBackoff
+	}
+	return wait
+}
+
+// slackErrorCode returns the error field of a Slack JSON response, if any.
+// The response body is buffered so that it can still be read by the caller.
+func slackErrorCode(resp *http.Response) string {
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		return ""
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+
+	var slackResponse struct {
+		Ok    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &slackResponse); err != nil || slackResponse.Ok {
+		return ""
+	}
+	return slackResponse.Error
+}
+
+// slackReadMethodPrefixes are the prefixes of the last part of the names of
+// the Slack methods that only read data, e.g. conversations.list or
+// admin.conversations.getTeams. They can safely be repeated.
+var slackReadMethodPrefixes = []string{"list", "get", "info", "history", "members", "lookup"}
+
+func isReadMethod(method string) bool {
+	if method == "auth.test" || method == "users.conversations" {
+		return true
+	}
+	name := method[strings.LastIndex(method, ".")+1:]
+	for _, prefix := range slackReadMethodPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNotSentError reports whether a request failed before it was sent, e.g.
+// because the connection was refused, so that Slack can't have processed it.
+func isNotSentError(err error) bool {
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	return (errors.As(err, &opErr) && opErr.Op == "dial") || errors.As(err, &dnsErr)
+}
+
+func isTemporaryNetworkError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+func canRewindRequest(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func rewindRequest(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't rewind request body: %w", err)
+	}
+	r := req.Clone(req.Context())
+	r.Body = body
+	return r, nil
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
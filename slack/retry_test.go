@@ -0,0 +1,162 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/require"
+)
+
+func testRetryClient(t *testing.T, maxRetries int, handler func(attempt int32, w http.ResponseWriter)) (*slack.Client, *int32) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("channel") != "C0123456789" {
+			// the request body must be replayed on every retry
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		handler(atomic.AddInt32(&attempts, 1), w)
+	}))
+	t.Cleanup(server.Close)
+
+	transport := newRetryTransport(http.DefaultTransport, maxRetries, time.Millisecond)
+	transport.minBackoff = time.Millisecond
+	client := slack.New("xoxb-test", slack.OptionAPIURL(server.URL+"/"), slack.OptionHTTPClient(&http.Client{Transport: transport}))
+	return client, &attempts
+}
+
+func writeSlackResponse(w http.ResponseWriter, body string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_, _ = fmt.Fprint(w, body)
+}
+
+func TestRetryTransport(t *testing.T) {
+	t.Run("retries rate limited calls after Retry-After", func(t *testing.T) {
+		client, attempts := testRetryClient(t, 3, func(attempt int32, w http.ResponseWriter) {
+			if attempt == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			writeSlackResponse(w, `{"ok": true}`)
+		})
+
+		require.NoError(t, client.ArchiveConversation("C0123456789"))
+		require.Equal(t, int32(2), atomic.LoadInt32(attempts))
+	})
+
+	t.Run("retries server errors and transient slack errors", func(t *testing.T) {
+		client, attempts := testRetryClient(t, 3, func(attempt int32, w http.ResponseWriter) {
+			switch attempt {
+			case 1:
+				w.WriteHeader(http.StatusBadGateway)
+			case 2:
+				writeSlackResponse(w, `{"ok": false, "error": "internal_error"}`)
+			case 3:
+				writeSlackResponse(w, `{"ok": false, "error": "fatal_error"}`)
+			default:
+				writeSlackResponse(w, `{"ok": true, "channel": {"id": "C0123456789"}}`)
+			}
+		})
+
+		_, err := client.GetConversationInfo(&slack.GetConversationInfoInput{ChannelID: "C0123456789"})
+		require.NoError(t, err)
+		require.Equal(t, int32(4), atomic.LoadInt32(attempts))
+	})
+
+	t.Run("does not retry server errors of writes", func(t *testing.T) {
+		client, attempts := testRetryClient(t, 3, func(_ int32, w http.ResponseWriter) {
+			w.WriteHeader(http.StatusBadGateway)
+		})
+
+		// the archive may have been processed before the error
+		require.Error(t, client.ArchiveConversation("C0123456789"))
+		require.Equal(t, int32(1), atomic.LoadInt32(attempts))
+	})
+
+	t.Run("does not retry other slack errors", func(t *testing.T) {
+		client, attempts := testRetryClient(t, 3, func(_ int32, w http.ResponseWriter) {
+			writeSlackResponse(w, `{"ok": false, "error": "channel_not_found"}`)
+		})
+
+		err := client.ArchiveConversation("C0123456789")
+		require.EqualError(t, err, "channel_not_found")
+		require.Equal(t, int32(1), atomic.LoadInt32(attempts))
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		client, attempts := testRetryClient(t, 2, func(_ int32, w http.ResponseWriter) {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+
+		err := client.ArchiveConversation("C0123456789")
+		require.IsType(t, &slack.RateLimitedError{}, err)
+		require.Equal(t, int32(3), atomic.LoadInt32(attempts))
+	})
+
+	t.Run("stops waiting when the context is canceled", func(t *testing.T) {
+		client, attempts := testRetryClient(t, 3, func(_ int32, w http.ResponseWriter) {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+		err := client.ArchiveConversationContext(ctx, "C0123456789")
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, int32(1), atomic.LoadInt32(attempts))
+	})
+
+	t.Run("gives up at once when the wait ends after the deadline", func(t *testing.T) {
+		client, attempts := testRetryClient(t, 3, func(_ int32, w http.ResponseWriter) {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		err := client.ArchiveConversationContext(ctx, "C0123456789")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "not retrying conversations.archive (rate_limited) in 1m0s, after the deadline")
+		require.NoError(t, ctx.Err(), "the deadline isn't reached")
+		require.Equal(t, int32(1), atomic.LoadInt32(attempts))
+	})
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	transport := newRetryTransport(nil, defaultMaxRetries, 10*time.Second)
+
+	require.Equal(t, time.Second, transport.backoff(0))
+	require.Equal(t, 4*time.Second, transport.backoff(2))
+	require.Equal(t, 10*time.Second, transport.backoff(4))
+	require.Equal(t, 10*time.Second, transport.backoff(100))
+}
+
+func TestIsReadMethod(t *testing.T) {
+	for _, method := range []string{"auth.test", "conversations.list", "conversations.info", "conversations.history",
+		"conversations.members", "users.lookupByEmail", "admin.conversations.getTeams", "usergroups.users.list", "users.conversations"} {
+		require.True(t, isReadMethod(method), method)
+	}
+	for _, method := range []string{"chat.postMessage", "bookmarks.add", "conversations.create", "conversations.inviteShared",
+		"conversations.archive", "admin.conversations.setTeams", "usergroups.users.update"} {
+		require.False(t, isReadMethod(method), method)
+	}
+}
+
+func TestIsNotSentError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	_, err := http.Post(url, "application/x-www-form-urlencoded", nil)
+	require.True(t, isNotSentError(err), "connection refused: %v", err)
+	require.False(t, isNotSentError(io.ErrUnexpectedEOF))
+}
@@ -1,8 +1,11 @@
 package slack
 
 import (
+	"flag"
 	"fmt"
+	"log"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -11,10 +14,58 @@ import (
 
 var slackClient *slack.Client
 
+// TestMain runs the tests against an in-process fake Slack API unless
+// SLACK_TOKEN is set, in which case they run against the real workspace.
+//
+// With SLACK_CASSETTE=record the calls to the workspace are recorded into
+// cassettes under testdata/cassettes, and with SLACK_CASSETTE=replay the
+// tests are run against those cassettes instead.
 func TestMain(m *testing.M) {
+	if mode := os.Getenv("SLACK_CASSETTE"); mode != "" {
+		setupCassettes(mode)
+	} else if os.Getenv("SLACK_TOKEN") == "" {
+		fake := newFakeSlack()
+		if err := os.Setenv("SLACK_TOKEN", fakeSlackToken); err != nil {
+			log.Fatalf("[ERROR] couldn't set SLACK_TOKEN: %s", err)
+		}
+		if err := os.Setenv("SLACK_API_URL", fake.apiURL()); err != nil {
+			log.Fatalf("[ERROR] couldn't set SLACK_API_URL: %s", err)
+		}
+	}
 	resource.TestMain(m)
 }
 
+func setupCassettes(mode string) {
+	upstream := slack.APIURL
+	switch mode {
+	case cassetteRecord:
+		if os.Getenv("SLACK_TOKEN") == "" {
+			log.Fatalf("[ERROR] SLACK_TOKEN must be set to record cassettes")
+		}
+		if apiURL := os.Getenv("SLACK_API_URL"); apiURL != "" {
+			upstream = strings.TrimSuffix(apiURL, "/") + "/"
+		}
+	case cassetteReplay:
+		if err := os.Setenv("SLACK_TOKEN", "xoxb-scrubbed"); err != nil {
+			log.Fatalf("[ERROR] couldn't set SLACK_TOKEN: %s", err)
+		}
+	default:
+		log.Fatalf("[ERROR] SLACK_CASSETTE must be either %s or %s, got %q", cassetteRecord, cassetteReplay, mode)
+	}
+
+	testCassettes = newCassetteServer(mode, upstream, cassetteDir)
+	if err := os.Setenv("SLACK_API_URL", testCassettes.apiURL()); err != nil {
+		log.Fatalf("[ERROR] couldn't set SLACK_API_URL: %s", err)
+	}
+
+	// Calls are attributed to the test that activated its cassette last, so
+	// the tests have to run one at a time.
+	flag.Parse()
+	if err := flag.Set("test.parallel", "1"); err != nil {
+		log.Fatalf("[ERROR] couldn't disable parallel tests: %s", err)
+	}
+}
+
 func sharedSlackClient() (interface{}, error) {
 	if slackClient != nil {
 		return slackClient, nil
@@ -25,5 +76,8 @@ func sharedSlackClient() (interface{}, error) {
 		return nil, fmt.Errorf("could not initialize Slack client. Set environment variable SLACK_TOKEN")
 	}
 
+	if apiURL, ok := os.LookupEnv("SLACK_API_URL"); ok {
+		return slack.New(token, slack.OptionAPIURL(apiURL)), nil
+	}
 	return slack.New(token), nil
 }
@@ -0,0 +1,52 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// Reconciling the members of a conversation takes a call per kicked
+	// member, and large channels are often rate limited.
+	defaultConversationTimeout       = 30 * time.Minute
+	defaultConversationReadTimeout   = 10 * time.Minute
+	defaultConversationDeleteTimeout = 10 * time.Minute
+
+	defaultUserGroupTimeout = 5 * time.Minute
+)
+
+func conversationTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultConversationTimeout),
+		Read:   schema.DefaultTimeout(defaultConversationReadTimeout),
+		Update: schema.DefaultTimeout(defaultConversationTimeout),
+		Delete: schema.DefaultTimeout(defaultConversationDeleteTimeout),
+	}
+}
+
+func userGroupTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultUserGroupTimeout),
+		Read:   schema.DefaultTimeout(defaultUserGroupTimeout),
+		Update: schema.DefaultTimeout(defaultUserGroupTimeout),
+		Delete: schema.DefaultTimeout(defaultUserGroupTimeout),
+	}
+}
+
+// withProgress returns err, saying how far the operation got when it failed
+// because its context is done, e.g. when its timeout is reached, so that it
+// can be resumed by the next apply.
+func withProgress(ctx context.Context, err error, format string, args ...interface{}) error {
+	progress := fmt.Sprintf(format, args...)
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return fmt.Errorf("timed out after %s: %w", progress, err)
+	case errors.Is(err, context.Canceled) || ctx.Err() != nil:
+		return fmt.Errorf("canceled after %s: %w", progress, err)
+	}
+	return err
+}
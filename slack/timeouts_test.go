@@ -0,0 +1,39 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithProgress(t *testing.T) {
+	err := errors.New("channel_not_found")
+	require.Equal(t, err, withProgress(context.Background(), err, "kicking %d members", 3), "other errors are returned as is")
+
+	ctx, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+	require.EqualError(t, withProgress(ctx, ctx.Err(), "kicking %d of %d members", 3, 10),
+		"timed out after kicking 3 of 10 members: context deadline exceeded")
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	require.EqualError(t, withProgress(ctx, ctx.Err(), "inviting %d users", 1000),
+		"canceled after inviting 1000 users: context canceled")
+}
+
+func TestResourceTimeouts(t *testing.T) {
+	for name, resource := range map[string]*schema.Resource{
+		"slack_conversation": resourceSlackConversation(),
+		"slack_usergroup":    resourceSlackUserGroup(),
+	} {
+		require.NotNil(t, resource.Timeouts, name)
+		d := resource.TestResourceData()
+		for _, key := range []string{schema.TimeoutCreate, schema.TimeoutRead, schema.TimeoutUpdate, schema.TimeoutDelete} {
+			require.NotEqual(t, schema.DefaultTimeout(nil), d.Timeout(key), "%s has a %s timeout", name, key)
+		}
+	}
+}